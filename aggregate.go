@@ -0,0 +1,164 @@
+package gobptree
+
+import (
+	"cmp"
+	"errors"
+)
+
+// Aggregator describes how the values of a tree are summarized by Aggregate.
+// Combine must be associative, and Identity must be its identity, so that
+// combining Identity with any value returns that value. Combine need not be
+// commutative, because values are always combined in the order of their keys.
+//
+//	// Sum of the values.
+//	gobptree.Aggregator[int64]{Combine: func(a, b int64) int64 { return a + b }}
+//
+//	// Maximum of the values.
+//	gobptree.Aggregator[int64]{Identity: math.MinInt64, Combine: func(a, b int64) int64 { return max(a, b) }}
+type Aggregator[V any] struct {
+	Identity V
+	Combine  func(a, b V) V
+}
+
+// NewAggregateTree returns a newly initialized Tree of the specified order,
+// whose keys are in ascending order, and which maintains the summary of the
+// values under each child of its internal nodes. See NewAggregateTreeFunc.
+func NewAggregateTree[K cmp.Ordered, V any](order int, aggregator Aggregator[V]) (*Tree[K, V], error) {
+	return NewAggregateTreeFunc[K, V](order, cmp.Compare[K], aggregator)
+}
+
+// NewAggregateTreeFunc returns a newly initialized Tree of the specified order,
+// whose keys are ordered by compare, and which maintains the summary of the
+// values under each child of its internal nodes, so that Aggregate visits the
+// two paths from the root to the leaves at either end of the range, rather
+// than every leaf in the range.
+//
+// Like NewOrderStatisticTreeFunc, maintaining the summaries costs some of the
+// parallelism of modifications. Every summary along the path from the root to
+// a leaf changes when a value in the leaf changes, so Insert, Update, Delete,
+// CompareAndSwap, and the other operations that modify the tree hold the lock
+// on every node along the path until they complete.
+//
+//	// Bytes transferred, keyed by timestamp.
+//	t, err := gobptree.NewAggregateTree[int64, int64](64, gobptree.Aggregator[int64]{
+//	    Combine: func(a, b int64) int64 { return a + b },
+//	})
+//	// ...
+//	total := t.Aggregate(start, end)
+func NewAggregateTreeFunc[K any, V any](order int, compare func(a, b K) int, aggregator Aggregator[V]) (*Tree[K, V], error) {
+	if aggregator.Combine == nil {
+		return nil, errors.New("cannot create tree without a combine function")
+	}
+	t, err := NewTreeFunc[K, V](order, compare)
+	if err != nil {
+		return nil, err
+	}
+	t.aggregator = &aggregator
+	return t, nil
+}
+
+// Aggregate returns the result of combining, in ascending order of their
+// keys, the values of every key in the tree that is greater than or equal to
+// lo and less than or equal to hi, or the identity of the tree's Aggregator
+// when there are none. It panics when the tree was not created by
+// NewAggregateTree or NewAggregateTreeFunc.
+//
+// Only the nodes along the paths to lo and hi are visited. The summaries that
+// internal nodes hold for their children stand in for every child in between.
+func (t *Tree[K, V]) Aggregate(lo, hi K) V {
+	if t.aggregator == nil {
+		panic("cannot aggregate tree created without an aggregator")
+	}
+	if t.compare(lo, hi) > 0 {
+		return t.aggregator.Identity
+	}
+
+	// Holding the lock on the root while descending along both paths prevents
+	// the tree from changing, because every modification of the tree holds the
+	// lock on the root until it completes.
+	root := t.lockRoot()
+	defer root.unlock()
+	return t.aggregate(root, lo, hi, true, true)
+}
+
+// aggregate returns the result of combining the values under the locked node
+// n whose keys are greater than or equal to lo when hasLo is true, and less
+// than or equal to hi when hasHi is true. Each child visited is locked until
+// the values under it have been combined.
+func (t *Tree[K, V]) aggregate(n node[K, V], lo, hi K, hasLo, hasHi bool) V {
+	if !hasLo && !hasHi {
+		return n.summary(t.aggregator)
+	}
+
+	if l, ok := n.(*leafNode[K, V]); ok {
+		first, last := 0, len(l.runts)
+		if hasLo {
+			first, _ = l.search(lo, t.compare)
+		}
+		if hasHi {
+			var found bool
+			if last, found = l.search(hi, t.compare); found {
+				last++
+			}
+		}
+		result := t.aggregator.Identity
+		for _, value := range l.values[first:last] {
+			result = t.aggregator.Combine(result, value)
+		}
+		return result
+	}
+
+	i := n.(*internalNode[K, V])
+	first, last := 0, len(i.children)-1
+	if hasLo {
+		first = searchLessThanOrEqualTo(lo, i.runts, t.compare)
+	}
+	if hasHi {
+		last = searchLessThanOrEqualTo(hi, i.runts, t.compare)
+	}
+
+	aggregateChild := func(index int, hasLo, hasHi bool) V {
+		child := i.children[index]
+		child.lock()
+		defer child.unlock()
+		return t.aggregate(child, lo, hi, hasLo, hasHi)
+	}
+
+	if first == last {
+		return aggregateChild(first, hasLo, hasHi)
+	}
+	// Every value under the children between the first and final children is
+	// within the range, and already summarized by this node.
+	result := aggregateChild(first, hasLo, false)
+	for _, summary := range i.summaries[first+1 : last] {
+		result = t.aggregator.Combine(result, summary)
+	}
+	return t.aggregator.Combine(result, aggregateChild(last, false, hasHi))
+}
+
+// summarize recomputes the summary of the locked child at index, when the tree
+// maintains summaries.
+func (i *internalNode[K, V]) summarize(index int) {
+	if i.summaries != nil {
+		i.summaries[index] = i.children[index].summary(i.aggregator)
+	}
+}
+
+// summary returns the result of combining the summaries of every child of this
+// node. It is only valid when the tree maintains summaries.
+func (i *internalNode[K, V]) summary(aggregator *Aggregator[V]) V {
+	result := aggregator.Identity
+	for _, summary := range i.summaries {
+		result = aggregator.Combine(result, summary)
+	}
+	return result
+}
+
+// summary returns the result of combining every value in this leaf.
+func (l *leafNode[K, V]) summary(aggregator *Aggregator[V]) V {
+	result := aggregator.Identity
+	for _, value := range l.values {
+		result = aggregator.Combine(result, value)
+	}
+	return result
+}
@@ -0,0 +1,229 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// sumAggregator sums the int values of a tree.
+var sumAggregator = Aggregator[interface{}]{
+	Identity: 0,
+	Combine:  func(a, b interface{}) interface{} { return a.(int) + b.(int) },
+}
+
+// ensureSummaries ensures every summary of every internal node of a tree that
+// maintains summaries is the summary of the values under the respective child.
+func ensureSummaries[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}]) {
+	t.Helper()
+
+	var walk func(n node[K, interface{}]) interface{}
+	walk = func(n node[K, interface{}]) interface{} {
+		t.Helper()
+		i, ok := n.(*internalNode[K, interface{}])
+		if !ok {
+			return n.summary(d.aggregator)
+		}
+		if got, want := len(i.summaries), len(i.children); got != want {
+			t.Errorf("length(summaries) GOT: %v; WANT: %v", got, want)
+			return d.aggregator.Identity
+		}
+		result := d.aggregator.Identity
+		for j, child := range i.children {
+			summary := walk(child)
+			if got, want := i.summaries[j], summary; got != want {
+				t.Errorf("summaries[%d] GOT: %v; WANT: %v", j, got, want)
+			}
+			result = d.aggregator.Combine(result, summary)
+		}
+		return result
+	}
+	walk(d.root)
+}
+
+func TestTreeAggregate(t *testing.T) {
+	forEachKeyType(t, testTreeAggregate[int32], testTreeAggregate[int64], testTreeAggregate[uint32], testTreeAggregate[uint64], testTreeAggregate[string])
+}
+
+func testTreeAggregate[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	// ensureAggregates compares Aggregate with the sum of the values expected
+	// in the tree.
+	ensureAggregates := func(t *testing.T, d *Tree[K, interface{}], m map[int]int, keyCount int) {
+		t.Helper()
+		for lo := 0; lo <= keyCount; lo += 7 {
+			for hi := lo - 1; hi <= keyCount; hi += 11 {
+				if hi < 0 {
+					continue
+				}
+				var want int
+				for key, value := range m {
+					if lo <= key && key <= hi {
+						want += value
+					}
+				}
+				if got := d.Aggregate(k(lo), k(hi)); got != want {
+					t.Errorf("Aggregate(%v, %v) GOT: %v; WANT: %v", lo, hi, got, want)
+				}
+			}
+		}
+	}
+
+	t.Run("requires combine function", func(t *testing.T) {
+		_, err := NewAggregateTree[K, interface{}](4, Aggregator[interface{}]{})
+		ensureError(t, err, "combine")
+	})
+	t.Run("requires aggregator", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		defer func() {
+			if r := recover(); r == nil {
+				t.Errorf("GOT: %v; WANT: panic", r)
+			}
+		}()
+		d.Aggregate(k(1), k(2))
+	})
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewAggregateTree[K, interface{}](4, sumAggregator)
+		ensureAggregates(t, d, nil, 10)
+		if got, want := d.Aggregate(k(5), k(1)), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("combines in key order", func(t *testing.T) {
+		// Concatenation is associative but not commutative.
+		d, _ := NewAggregateTree[K, interface{}](4, Aggregator[interface{}]{
+			Identity: "",
+			Combine:  func(a, b interface{}) interface{} { return a.(string) + b.(string) },
+		})
+		for _, v := range rand.New(rand.NewSource(1)).Perm(26) {
+			d.Insert(k(v), string(rune('a'+v)))
+		}
+		if got, want := d.Aggregate(k(0), k(25)), "abcdefghijklmnopqrstuvwxyz"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := d.Aggregate(k(3), k(9)), "defghij"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 200
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			d, _ := NewAggregateTree[K, interface{}](order, sumAggregator)
+			m := make(map[int]int)
+
+			for step := 0; step < 600; step++ {
+				v := r.Intn(keyCount)
+				value := r.Intn(1000)
+				switch r.Intn(9) {
+				case 0:
+					d.Delete(k(v))
+					delete(m, v)
+				case 1:
+					d.Update(k(v), func(interface{}, bool) interface{} { return value })
+					m[v] = value
+				case 2:
+					if _, ok := m[v]; !ok {
+						m[v] = value
+					}
+					d.LoadOrStore(k(v), value)
+				case 3:
+					remove := r.Intn(2) == 0
+					d.Compute(k(v), func(interface{}, bool) (interface{}, ComputeAction) {
+						if remove {
+							return nil, ComputeDelete
+						}
+						return value, ComputeStore
+					})
+					if remove {
+						delete(m, v)
+					} else {
+						m[v] = value
+					}
+				case 4:
+					hi := v + r.Intn(20)
+					d.DeleteRange(k(v), k(hi))
+					for i := v; i <= hi; i++ {
+						delete(m, i)
+					}
+				case 5:
+					keys := make([]K, 10)
+					for i := range keys {
+						key := r.Intn(keyCount)
+						keys[i] = k(key)
+						delete(m, key)
+					}
+					d.DeleteMany(keys)
+				case 6:
+					items := make([]int, 30)
+					for i := range items {
+						items[i] = r.Intn(keyCount)
+						m[items[i]] = value
+					}
+					d.InsertMany(func(yield func(K, interface{}) bool) {
+						for _, item := range items {
+							if !yield(k(item), value) {
+								return
+							}
+						}
+					})
+				case 7:
+					if old, ok := m[v]; ok {
+						d.CompareAndSwap(k(v), old, value)
+						m[v] = value
+					}
+				default:
+					d.Insert(k(v), value)
+					m[v] = value
+				}
+
+				ensureSummaries(t, d)
+				if step%20 == 0 {
+					ensureAggregates(t, d, m, keyCount)
+					ensureStructure(t, d)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 500
+
+		d, _ := NewAggregateTree[K, interface{}](4, sumAggregator)
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := g; i < goroutines*perGoroutine; i += goroutines {
+					d.Insert(k(i), 1)
+					d.Aggregate(k(0), k(i))
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := g; i < goroutines*perGoroutine; i += 2 * goroutines {
+					d.Delete(k(i))
+					d.CompareAndSwap(k(i+goroutines), 1, 2)
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		ensureSummaries(t, d)
+		// Every remaining key was swapped from 1 to 2.
+		if got, want := d.Aggregate(k(0), k(goroutines*perGoroutine)), goroutines*perGoroutine; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
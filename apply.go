@@ -0,0 +1,167 @@
+package gobptree
+
+import "sync/atomic"
+
+// Batch is a sequence of insertions, updates, and deletions that Apply applies
+// to a tree all at once. The zero value is an empty batch.
+//
+//	// Move the value of a key to another key.
+//	var b gobptree.Batch[string, int]
+//	b.Delete("a")
+//	b.Insert("b", value)
+//	t.Apply(&b)
+type Batch[K any, V any] struct {
+	ops []batchOp[K, V]
+}
+
+// batchOp is a single operation of a batch.
+type batchOp[K any, V any] struct {
+	key      K
+	value    V
+	callback func(V, bool) V // when not nil the operation is an update
+	delete   bool
+}
+
+// Insert appends to the batch the insertion of the key-value pair, replacing
+// the value of key when key is already in the tree, like Tree.Insert.
+func (b *Batch[K, V]) Insert(key K, value V) {
+	b.ops = append(b.ops, batchOp[K, V]{key: key, value: value})
+}
+
+// Update appends to the batch the update of the value of key, which stores the
+// value returned by callback, like Tree.Update. The callback observes the
+// operations earlier in the batch.
+func (b *Batch[K, V]) Update(key K, callback func(V, bool) V) {
+	b.ops = append(b.ops, batchOp[K, V]{key: key, callback: callback})
+}
+
+// Delete appends to the batch the removal of key from the tree, like
+// Tree.Delete.
+func (b *Batch[K, V]) Delete(key K) {
+	b.ops = append(b.ops, batchOp[K, V]{key: key, delete: true})
+}
+
+// Len returns the number of operations in the batch.
+func (b *Batch[K, V]) Len() int { return len(b.ops) }
+
+// Apply applies the operations of the batch to the tree in order, so that every
+// operation becomes visible to the other operations on the tree at once. No
+// call to Search observes some of the operations but not the others, nor does
+// a cursor return key-value pairs from the tree both before and after the
+// batch, nor does any operation that modifies the tree, such as Update, run
+// while the batch is applied. The cursor returned by NewBufferedScanner is the
+// exception: it holds no lock between calls to Scan, and searches the tree as
+// it is each time it refills its buffer.
+//
+// Apply waits for the operations modifying the tree to complete, and applies
+// the batch to a copy of the tree, which shares every node with the tree like
+// a clone, before the root of the copy replaces the root of the tree. Cursors
+// created before the batch search the tree as it was before it, so while any
+// cursor of the tree is open, the leaves of the tree are not linked, like the
+// leaves of a tree that has been cloned. They are linked again once the final
+// cursor is closed or exhausted, visiting only the nodes the batch copied. The
+// callbacks of the updates in the batch must not modify the tree.
+func (t *Tree[K, V]) Apply(b *Batch[K, V]) {
+	if len(b.ops) == 0 {
+		return
+	}
+
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+	t.apply(b.ops)
+}
+
+// apply applies the operations to the tree all at once. The caller must hold
+// the tree's write mutex exclusively.
+func (t *Tree[K, V]) apply(ops []batchOp[K, V]) {
+	t.viewFresh.Store(false)
+
+	// The tree as it was before the batch, which cursors created before the
+	// batch search from now on.
+	before := t.frozen()
+	// The tree the batch is applied to, which modifies the nodes of the context
+	// of this tree after the first freeze, and copies every other node.
+	after := t.frozen()
+
+	for _, op := range ops {
+		switch {
+		case op.delete:
+			after.Delete(op.key)
+		case op.callback != nil:
+			after.Update(op.key, op.callback)
+		default:
+			after.Insert(op.key, op.value)
+		}
+	}
+
+	// No operation modifies the tree while the write mutex is held
+	// exclusively, so the root may be replaced without holding its lock.
+	t.rootMutex.Lock()
+	if e := t.epoch; e != nil {
+		e.frozen.Store(before)
+		t.epoch = nil
+	}
+	t.root = after.root
+	t.rootMutex.Unlock()
+
+	t.length.Store(after.length.Load())
+	t.cow.Store(after.cow.Load())
+	// Cursors created before the batch search the tree as it was before it,
+	// but once there are none, the leaves of the tree may be linked again.
+	t.relink()
+}
+
+// epoch is the span of time between two batches applied to a tree. Cursors
+// record the epoch in which they were created, and once a batch ends that
+// epoch, search the tree as it was before the batch rather than the tree, so
+// that a cursor never returns the key-value pairs of the tree both before and
+// after a batch.
+type epoch[K any, V any] struct {
+	// the tree before the batch that ended the epoch, whose nodes are never
+	// modified again
+	frozen atomic.Pointer[Tree[K, V]]
+}
+
+// currentEpoch returns the epoch of the tree's root.
+func (t *Tree[K, V]) currentEpoch() *epoch[K, V] {
+	t.rootMutex.RLock()
+	e := t.epoch
+	t.rootMutex.RUnlock()
+	if e != nil {
+		return e
+	}
+
+	t.rootMutex.Lock()
+	defer t.rootMutex.Unlock()
+	if t.epoch == nil {
+		t.epoch = &epoch[K, V]{}
+	}
+	return t.epoch
+}
+
+// tree returns the tree as it was in this epoch: the tree before the batch
+// that ended the epoch, or t while the epoch has not ended.
+func (e *epoch[K, V]) tree(t *Tree[K, V]) *Tree[K, V] {
+	if frozen := e.frozen.Load(); frozen != nil {
+		return frozen
+	}
+	return t
+}
+
+// lockLeafIn invokes lock with the tree as it was in epoch e, and returns the
+// leaf node and index it returns. When a batch ends the epoch while lock is
+// searching the tree, lock might have visited the tree both before and after
+// the batch, so the leaf is released and the search repeated in the tree as
+// it was before the batch.
+func (t *Tree[K, V]) lockLeafIn(e *epoch[K, V], lock func(*Tree[K, V]) (*leafNode[K, V], int)) (*leafNode[K, V], int) {
+	for {
+		s := e.tree(t)
+		l, index := lock(s)
+		if s == e.tree(t) {
+			return l, index
+		}
+		if l != nil {
+			l.unlock()
+		}
+	}
+}
@@ -0,0 +1,336 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+func TestTreeApply(t *testing.T) {
+	forEachKeyType(t, testTreeApply[int32], testTreeApply[int64], testTreeApply[uint32], testTreeApply[uint64], testTreeApply[string])
+}
+
+func testTreeApply[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const keyCount = 200
+
+	newTree := func(order int) *Tree[K, interface{}] {
+		d, _ := NewTree[K, interface{}](order)
+		for i := 0; i < keyCount; i++ {
+			d.Insert(k(i), 0)
+		}
+		return d
+	}
+
+	t.Run("empty batch", func(t *testing.T) {
+		d := newTree(4)
+		d.Apply(&Batch[K, interface{}]{})
+		if d.cow.Load() != nil {
+			t.Errorf("GOT: %v; WANT: %v", d.cow.Load(), nil)
+		}
+		ensureLeafLinks(t, d)
+	})
+	t.Run("applies operations in order", func(t *testing.T) {
+		plain, _ := NewTree[K, interface{}](4)
+		counted, _ := NewOrderStatisticTree[K, interface{}](4)
+		aggregated, _ := NewAggregateTree[K, interface{}](4, sumAggregator)
+		for _, d := range []*Tree[K, interface{}]{plain, counted, aggregated} {
+			for i := 0; i < keyCount; i += 2 {
+				d.Insert(k(i), i)
+			}
+
+			var b Batch[K, interface{}]
+			b.Insert(k(1), 1)
+			b.Update(k(1), func(value interface{}, ok bool) interface{} {
+				if !ok {
+					t.Errorf("GOT: %v; WANT: %v", ok, true)
+				}
+				return value.(int) + 10
+			})
+			b.Delete(k(2))
+			b.Update(k(3), func(value interface{}, ok bool) interface{} {
+				if ok {
+					t.Errorf("GOT: %v; WANT: %v", ok, false)
+				}
+				return 3
+			})
+			for i := 10; i < 100; i++ {
+				b.Delete(k(i))
+			}
+			if got, want := b.Len(), 94; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			d.Apply(&b)
+
+			if value, ok := d.Search(k(1)); !ok || value != 11 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 11, true)
+			}
+			if value, ok := d.Search(k(2)); ok {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, nil, false)
+			}
+			if value, ok := d.Search(k(3)); !ok || value != 3 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 3, true)
+			}
+			expected := []int{0, 1, 3, 4, 6, 8}
+			for i := 100; i < keyCount; i += 2 {
+				expected = append(expected, i)
+			}
+			ensureScan(t, d, k(0), k.slice(expected...))
+			if got, want := d.Len(), len(expected); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			ensureStructure(t, d)
+			if d.counted {
+				ensureCounts(t, d)
+			}
+			if d.aggregator != nil {
+				ensureSummaries(t, d)
+			}
+		}
+	})
+	t.Run("leaves are linked once no cursor is open", func(t *testing.T) {
+		d := newTree(4)
+
+		// The cursor searches the tree as it was before the batch, whose
+		// leaves the tree shares until the cursor is closed.
+		c := d.NewReverseScanner(k(keyCount))
+		var b Batch[K, interface{}]
+		for i := 0; i < keyCount; i += 2 {
+			b.Delete(k(i))
+		}
+		for i := keyCount; i < 2*keyCount; i++ {
+			b.Insert(k(i), 0)
+		}
+		d.Apply(&b)
+		if d.cow.Load() == nil {
+			t.Errorf("GOT: %v; WANT: %v", nil, "context")
+		}
+		c.Close()
+
+		if got := d.cow.Load(); got != nil {
+			t.Errorf("GOT: %v; WANT: %v", got, nil)
+		}
+		ensureLeafLinks(t, d)
+		var expected []int
+		for i := 1; i < 2*keyCount; i++ {
+			if i >= keyCount || i%2 == 1 {
+				expected = append(expected, i)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		slices.Reverse(expected)
+		ensureReverseScan(t, d, k(2*keyCount), k.slice(expected...))
+		ensureStructure(t, d)
+
+		// Without an open cursor, the leaves are linked by the batch itself.
+		d.Apply(&b)
+		if got := d.cow.Load(); got != nil {
+			t.Errorf("GOT: %v; WANT: %v", got, nil)
+		}
+		ensureLeafLinks(t, d)
+	})
+	t.Run("cursors do not observe later batches", func(t *testing.T) {
+		cursors := map[string]func(d *Tree[K, interface{}]) *Cursor[K, interface{}]{
+			"scanner": func(d *Tree[K, interface{}]) *Cursor[K, interface{}] { return d.NewScanner(k(0)) },
+			"range scanner": func(d *Tree[K, interface{}]) *Cursor[K, interface{}] {
+				return d.NewRangeScanner(k(0), k(keyCount), RangeOptions{})
+			},
+			"reverse scanner": func(d *Tree[K, interface{}]) *Cursor[K, interface{}] { return d.NewReverseScanner(k(keyCount)) },
+		}
+		for name, newCursor := range cursors {
+			for _, clone := range []bool{false, true} {
+				d := newTree(4)
+				if clone {
+					// The leaves of a tree that has been cloned are not linked.
+					d.Clone()
+				}
+
+				c := newCursor(d)
+				c.Scan()
+
+				// Moves the value at either end of the tree to the other end.
+				var b Batch[K, interface{}]
+				b.Delete(k(keyCount - 1))
+				b.Insert(k(0), 1)
+				b.Delete(k(0))
+				b.Insert(k(keyCount-1), 1)
+				b.Insert(k(keyCount), 0)
+				d.Apply(&b)
+
+				count := 1
+				for c.Scan() {
+					if _, value := c.Pair(); value != 0 {
+						t.Errorf("%s: GOT: %v; WANT: %v", name, value, 0)
+					}
+					count++
+				}
+				if got, want := count, keyCount; got != want {
+					t.Errorf("%s: GOT: %v; WANT: %v", name, got, want)
+				}
+
+				if value, ok := d.Search(k(keyCount - 1)); !ok || value != 1 {
+					t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 1, true)
+				}
+				if got, want := d.Len(), keyCount; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				ensureStructure(t, d)
+			}
+		}
+	})
+	t.Run("buffered cursors observe later batches", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 20; i += 2 {
+			d.Insert(k(i), i)
+		}
+
+		c := d.NewBufferedScanner(k(0))
+		c.Scan()
+
+		var b Batch[K, interface{}]
+		b.Insert(k(21), 21)
+		d.Apply(&b)
+		// Pairs inserted after the final key returned are returned, whether
+		// inserted by a batch or afterwards.
+		d.Insert(k(15), 15)
+
+		keys := []K{k(0)}
+		for c.Scan() {
+			key, _ := c.Pair()
+			keys = append(keys, key)
+		}
+		ensureKeys(t, keys, k.slice(0, 2, 4, 6, 8, 10, 12, 14, 15, 16, 18, 21))
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const readers = 4
+		const batches = 300
+
+		d := newTree(4)
+
+		var done sync.WaitGroup
+		var wg sync.WaitGroup
+		done.Add(1)
+		go func() {
+			defer done.Done()
+			r := rand.New(rand.NewSource(1))
+			for n := 1; n <= batches; n++ {
+				// Moves a unit between two keys, keeping the sum of their
+				// values zero, and records n at either end of the tree.
+				from, to := 1+r.Intn(keyCount-1), 1+r.Intn(keyCount-1)
+				var b Batch[K, interface{}]
+				b.Update(k(from), func(value interface{}, _ bool) interface{} { return value.(int) - 1 })
+				b.Update(k(to), func(value interface{}, _ bool) interface{} { return value.(int) + 1 })
+				b.Insert(k(0), n)
+				b.Insert(k(3*keyCount), n)
+				d.Apply(&b)
+			}
+		}()
+
+		wg.Add(readers + 1)
+		go func() {
+			defer wg.Done()
+			// Modifies the structure of the tree without changing the sum of
+			// its values.
+			r := rand.New(rand.NewSource(2))
+			for n := 0; n < batches; n++ {
+				key := keyCount + r.Intn(keyCount)
+				if r.Intn(2) == 0 {
+					d.Insert(k(key), 0)
+				} else {
+					d.Delete(k(key))
+				}
+			}
+		}()
+		for g := 0; g < readers; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for n := 0; n < 50; n++ {
+					// The value at the end of the tree is recorded by the same
+					// batch as the value at its start.
+					first, _ := d.Search(k(0))
+					second, _ := d.Search(k(3 * keyCount))
+					if second == nil {
+						second = 0
+					}
+					if second.(int) < first.(int) {
+						t.Errorf("GOT: %v; WANT: at least %v", second, first)
+					}
+
+					// Buffered cursors observe the batches applied while they
+					// scan, so only the others are checked.
+					var c *Cursor[K, interface{}]
+					if (g+n)%2 == 0 {
+						c = d.NewScanner(k(0))
+					} else {
+						c = d.NewReverseScanner(k(3 * keyCount))
+					}
+					var sum int
+					for c.Scan() {
+						if key, value := c.Pair(); k(0) < key && key < k(keyCount) {
+							sum += value.(int)
+						}
+					}
+					if sum != 0 {
+						t.Errorf("GOT: %v; WANT: %v", sum, 0)
+					}
+				}
+			}(g)
+		}
+		wg.Wait()
+		done.Wait()
+
+		var sum int
+		for key, value := range d.All() {
+			if k(0) < key && key < k(keyCount) {
+				sum += value.(int)
+			}
+		}
+		if sum != 0 {
+			t.Errorf("GOT: %v; WANT: %v", sum, 0)
+		}
+		ensureStructure(t, d)
+	})
+}
+
+// BenchmarkScanAfterApply compares scanning a tree whose leaves are linked with
+// scanning the same tree after a batch, and after it has been cloned, when the
+// leaves are not linked.
+func BenchmarkScanAfterApply(b *testing.B) {
+	const keyCount = 1 << 16
+
+	newTree := func() *Tree[int, interface{}] {
+		d, _ := NewTree[int, interface{}](64)
+		for i := 0; i < keyCount; i++ {
+			d.Insert(i, nil)
+		}
+		return d
+	}
+	scan := func(b *testing.B, d *Tree[int, interface{}]) {
+		for n := 0; n < b.N; n++ {
+			c := d.NewScanner(0)
+			for c.Scan() {
+			}
+		}
+	}
+
+	b.Run("Linked", func(b *testing.B) {
+		scan(b, newTree())
+	})
+
+	b.Run("Apply", func(b *testing.B) {
+		d := newTree()
+		var batch Batch[int, interface{}]
+		for i := 0; i < keyCount; i += 64 {
+			batch.Insert(i, 0)
+		}
+		d.Apply(&batch)
+		scan(b, d)
+	})
+
+	b.Run("Clone", func(b *testing.B) {
+		d := newTree()
+		d.Clone()
+		scan(b, d)
+	})
+}
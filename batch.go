@@ -0,0 +1,196 @@
+package gobptree
+
+import (
+	"cmp"
+	"iter"
+	"slices"
+)
+
+// pair is a key-value pair held by a batch operation, along with its position
+// in the batch.
+type pair[K any, V any] struct {
+	key   K
+	value V
+	index int
+}
+
+// InsertMany inserts every key-value pair yielded by pairs into the tree,
+// replacing the existing value of each key already in the tree. When pairs
+// yields the same key more than once, the final value yielded for the key is
+// stored.
+//
+// Rather than descending from the root of the tree for each pair, the pairs
+// are sorted, and every pair that belongs in the same leaf node is stored
+// during a single descent, which pre-emptively splits full nodes like Insert
+// does. A leaf node is only locked while its pairs are stored, so other
+// operations may observe some pairs of the batch before others.
+//
+//	t.InsertMany(maps.All(m))
+func (t *Tree[K, V]) InsertMany(pairs iter.Seq2[K, V]) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	var batch []pair[K, V]
+	for key, value := range pairs {
+		batch = append(batch, pair[K, V]{key: key, value: value, index: len(batch)})
+	}
+	// Ordering pairs with the same key by their position leaves the final value
+	// yielded for a key after the others, so it is the value stored.
+	slices.SortFunc(batch, func(a, b pair[K, V]) int {
+		if c := t.compare(a.key, b.key); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.index, b.index)
+	})
+
+	for len(batch) > 0 {
+		ln, hi, bounded, ancestors := t.lockLeafForInsertBounded(batch[0].key)
+		var inserted int
+		if _, replaced := ln.insert(batch[0].key, batch[0].value, t.compare); !replaced {
+			inserted++
+		}
+		batch = batch[1:]
+
+		// Store the following pairs while they belong in this leaf, and it
+		// has room for them without being split.
+		for len(batch) > 0 && len(ln.runts) < t.order {
+			if bounded && t.compare(batch[0].key, hi) >= 0 {
+				break
+			}
+			if _, replaced := ln.insert(batch[0].key, batch[0].value, t.compare); !replaced {
+				inserted++
+			}
+			batch = batch[1:]
+		}
+		// Count the pairs inserted into this leaf before releasing it, so that
+		// Clear cannot reset the count between the two.
+		t.length.Add(int64(inserted))
+		t.unlockPath(ln, ancestors, inserted)
+	}
+}
+
+// DeleteMany removes the key-value pair of every key from the tree, and
+// returns the number of pairs removed.
+//
+// Rather than descending from the root of the tree for each key, the keys are
+// sorted, and every key that belongs in the same leaf node is removed during a
+// single descent, which pre-emptively restores nodes that might become too
+// small like Delete does. A leaf node is only locked while its keys are
+// removed, so other operations may observe the removal of some keys of the
+// batch before others.
+func (t *Tree[K, V]) DeleteMany(keys []K) int {
+	sorted := slices.Clone(keys)
+	slices.SortFunc(sorted, t.compare)
+	sorted = slices.CompactFunc(sorted, func(a, b K) bool { return t.compare(a, b) == 0 })
+	if len(sorted) == 0 {
+		return 0
+	}
+
+	t.beginWrite()
+	defer t.endWrite()
+
+	var removed int
+	for len(sorted) > 0 {
+		ln, hi, bounded, ancestors := t.lockLeafForDeleteBounded(sorted[0])
+		var deleted int
+		if _, found, _ := ln.deleteKey(t.order, sorted[0], t.compare); found {
+			deleted++
+		}
+		sorted = sorted[1:]
+
+		// Remove the following keys while they belong in this leaf, and it
+		// holds more than the minimum number of keys, so it cannot become too
+		// small.
+		for len(sorted) > 0 && len(ln.runts) > t.order {
+			if bounded && t.compare(sorted[0], hi) >= 0 {
+				break
+			}
+			if _, found, _ := ln.deleteKey(t.order, sorted[0], t.compare); found {
+				deleted++
+			}
+			sorted = sorted[1:]
+		}
+		// Count the keys removed from this leaf before releasing it, so that
+		// Clear cannot reset the count between the two.
+		t.length.Add(-int64(deleted))
+		t.unlockPath(ln, ancestors, -deleted)
+		removed += deleted
+	}
+	return removed
+}
+
+// MultiGet returns the value associated with each key from the tree, along
+// with whether each key was found, in the same order as keys.
+//
+// Rather than descending from the root of the tree for each key, the keys are
+// sorted, and every key under the same node is looked up while its lock is
+// held, so neighbouring keys share the path from the root to the subtree they
+// have in common. Each node remains locked until the keys under its children
+// have been looked up, except that like Search, a node is unlocked as soon as
+// the lock on the child holding every remaining key has been acquired.
+//
+//	values, found := t.MultiGet(ids)
+func (t *Tree[K, V]) MultiGet(keys []K) ([]V, []bool) {
+	values := make([]V, len(keys))
+	found := make([]bool, len(keys))
+	if len(keys) == 0 {
+		return values, found
+	}
+
+	positions := make([]int, len(keys))
+	for i := range positions {
+		positions[i] = i
+	}
+	slices.SortFunc(positions, func(a, b int) int { return t.compare(keys[a], keys[b]) })
+
+	sorted := make([]K, len(keys))
+	for i, position := range positions {
+		sorted[i] = keys[position]
+	}
+
+	t.multiGet(t.lockRoot(), sorted, positions, values, found)
+	return values, found
+}
+
+// multiGet looks up the sorted keys under the locked node n, storing the
+// result for each key at its position in values and found, and unlocks n
+// before it returns.
+func (t *Tree[K, V]) multiGet(n node[K, V], keys []K, positions []int, values []V, found []bool) {
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		for {
+			index := searchLessThanOrEqualTo(keys[0], parent.runts, t.compare)
+			end := len(keys)
+			if index < len(parent.runts)-1 {
+				end = 1
+				for end < len(keys) && t.compare(keys[end], parent.runts[index+1]) < 0 {
+					end++
+				}
+			}
+
+			child := parent.children[index]
+			child.lock()
+			if end == len(keys) {
+				// Every remaining key is under this child, so there is no need
+				// to return to this node.
+				parent.unlock()
+				n = child
+				break
+			}
+			t.multiGet(child, keys[:end], positions[:end], values, found)
+			keys, positions = keys[end:], positions[end:]
+		}
+	}
+
+	l := n.(*leafNode[K, V])
+	var index int
+	for i, key := range keys {
+		// Keys are sorted, so each search begins where the previous ended.
+		index += searchGreaterThanOrEqualTo(key, l.runts[index:], t.compare)
+		if index < len(l.runts) && t.compare(key, l.runts[index]) == 0 {
+			values[positions[i]] = l.values[index]
+			found[positions[i]] = true
+		}
+	}
+	l.unlock()
+}
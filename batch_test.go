@@ -0,0 +1,483 @@
+package gobptree
+
+import (
+	"cmp"
+	"iter"
+	"math/rand"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestTreeInsertMany(t *testing.T) {
+	forEachKeyType(t, testTreeInsertMany[int32], testTreeInsertMany[int64], testTreeInsertMany[uint32], testTreeInsertMany[uint64], testTreeInsertMany[string])
+}
+
+func testTreeInsertMany[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	pairs := func(items ...int) iter.Seq2[K, interface{}] {
+		return func(yield func(K, interface{}) bool) {
+			for _, item := range items {
+				if !yield(k(item), item) {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("empty batch", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		d.InsertMany(pairs())
+		ensureScan(t, d, k(0), nil)
+		if got, want := d.Len(), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("replaces values", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 10; i++ {
+			d.Insert(k(i), "old")
+		}
+		d.InsertMany(func(yield func(K, interface{}) bool) {
+			_ = yield(k(3), "first") && yield(k(12), "new") && yield(k(3), "second")
+		})
+
+		if got, want := d.Len(), 11; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		// The final value yielded for a key is stored.
+		if value, _ := d.Search(k(3)); value != "second" {
+			t.Errorf("GOT: %v; WANT: %v", value, "second")
+		}
+		if value, _ := d.Search(k(12)); value != "new" {
+			t.Errorf("GOT: %v; WANT: %v", value, "new")
+		}
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 1024
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8, 32} {
+			d, _ := NewTree[K, interface{}](order)
+			m := make(map[int]bool)
+
+			for step := 0; step < 20; step++ {
+				batch := make([]int, r.Intn(200))
+				for i := range batch {
+					batch[i] = r.Intn(keyCount)
+					m[batch[i]] = true
+				}
+				d.InsertMany(pairs(batch...))
+
+				var expected []int
+				for i := 0; i < keyCount; i++ {
+					if m[i] {
+						expected = append(expected, i)
+						if value, ok := d.Search(k(i)); !ok || value != i {
+							t.Errorf("KEY: %v; GOT: %v, %v; WANT: %v, %v", i, value, ok, i, true)
+						}
+					}
+				}
+				ensureScan(t, d, k(0), k.slice(expected...))
+				ensureLeafLinks(t, d)
+				ensureStructure(t, d)
+				if got, want := d.Len(), len(expected); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 1000
+
+		d, _ := NewTree[K, interface{}](8)
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				var batch []int
+				for i := g; i < goroutines*perGoroutine; i += goroutines {
+					if batch = append(batch, i); len(batch) == 50 {
+						d.InsertMany(pairs(batch...))
+						batch = batch[:0]
+					}
+				}
+				d.InsertMany(pairs(batch...))
+			}(g)
+		}
+		wg.Wait()
+
+		expected := make([]int, goroutines*perGoroutine)
+		for i := range expected {
+			expected[i] = i
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+		if got, want := d.Len(), len(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestTreeDeleteMany(t *testing.T) {
+	forEachKeyType(t, testTreeDeleteMany[int32], testTreeDeleteMany[int64], testTreeDeleteMany[uint32], testTreeDeleteMany[uint64], testTreeDeleteMany[string])
+}
+
+func testTreeDeleteMany[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		if got, want := d.DeleteMany(k.slice(1, 2, 3)), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := d.DeleteMany(nil), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("does not modify keys", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 10; i++ {
+			d.Insert(k(i), i)
+		}
+		keys := k.slice(7, 2, 2, 42, 5)
+		if got, want := d.DeleteMany(keys), 3; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		for i, want := range k.slice(7, 2, 2, 42, 5) {
+			if got := keys[i]; got != want {
+				t.Errorf("keys[%d] GOT: %v; WANT: %v", i, got, want)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(0, 1, 3, 4, 6, 8, 9))
+	})
+	t.Run("every key", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		keys := make([]int, 500)
+		for i := range keys {
+			keys[i] = i
+			d.Insert(k(i), i)
+		}
+		if got, want := d.DeleteMany(k.slice(keys...)), len(keys); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureNode(t, d.root, node[K, interface{}](k.leafFrom(nil)))
+
+		// The tree remains usable.
+		d.Insert(k(5), k(5))
+		ensureScan(t, d, k(0), k.slice(5))
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 1024
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8, 32} {
+			d, _ := NewTree[K, interface{}](order)
+			m := make(map[int]bool)
+
+			for step := 0; step < 50; step++ {
+				for i := 0; i < 100; i++ {
+					v := r.Intn(keyCount)
+					d.Insert(k(v), v)
+					m[v] = true
+				}
+
+				batch := make([]int, r.Intn(150))
+				var want int
+				for i := range batch {
+					batch[i] = r.Intn(keyCount)
+					if m[batch[i]] {
+						delete(m, batch[i])
+						want++
+					}
+				}
+				if got := d.DeleteMany(k.slice(batch...)); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+
+				var expected []int
+				for i := 0; i < keyCount; i++ {
+					if m[i] {
+						expected = append(expected, i)
+					}
+				}
+				ensureScan(t, d, k(0), k.slice(expected...))
+				ensureLeafLinks(t, d)
+				ensureStructure(t, d)
+				if got, want := d.Len(), len(expected); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("counted and aggregated trees", func(t *testing.T) {
+		counted, _ := NewOrderStatisticTree[K, interface{}](4)
+		aggregated, _ := NewAggregateTree[K, interface{}](4, sumAggregator)
+		for _, d := range []*Tree[K, interface{}]{counted, aggregated} {
+			for i := 0; i < 500; i++ {
+				d.Insert(k(i), i)
+			}
+			var batch []int
+			for i := 0; i < 500; i += 3 {
+				batch = append(batch, i)
+			}
+			if got, want := d.DeleteMany(k.slice(batch...)), len(batch); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			if got, want := d.Len(), 500-len(batch); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			ensureStructure(t, d)
+			if d.counted {
+				ensureCounts(t, d)
+			}
+			if d.aggregator != nil {
+				ensureSummaries(t, d)
+			}
+		}
+	})
+	t.Run("releases the root before each leaf", func(t *testing.T) {
+		leaf6 := k.leafFrom(nil, 60, 61, 62)
+		leaf5 := k.leafFrom(leaf6, 50, 51, 52)
+		leaf4 := k.leafFrom(leaf5, 40, 41, 42)
+		leaf3 := k.leafFrom(leaf4, 30, 31, 32)
+		leaf2 := k.leafFrom(leaf3, 20, 21, 22)
+		leaf1 := k.leafFrom(leaf2, 10, 11, 12)
+		leaf2.prev.Store(leaf1)
+		leaf3.prev.Store(leaf2)
+		leaf4.prev.Store(leaf3)
+		leaf5.prev.Store(leaf4)
+		leaf6.prev.Store(leaf5)
+		right := internalFromLeaves(leaf4, leaf5, leaf6)
+		root := internalFrom[K](internalFromLeaves(leaf1, leaf2, leaf3), right)
+		d := &Tree[K, interface{}]{root: root, compare: cmp.Compare[K], order: 2}
+		d.length.Store(18)
+
+		// The cursor holds the lock on the final leaf, so DeleteMany waits for
+		// it after removing the key from the first leaf.
+		c := d.NewScanner(k(60))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			if got, want := d.DeleteMany(k.slice(10, 61)), 2; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		}()
+		// Wait for DeleteMany to lock the parent of the final leaf, which it
+		// holds while it waits for the lock on the leaf.
+		for right.mutex.TryLock() {
+			right.mutex.Unlock()
+			runtime.Gosched()
+		}
+
+		// Were DeleteMany to hold the lock on the root until it completes, no
+		// other operation could reach the first leaf.
+		if value, ok := d.Search(k(11)); !ok || value != k(11) {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, k(11), true)
+		}
+		c.Close()
+		<-done
+
+		ensureScan(t, d, k(0), k.slice(11, 12, 20, 21, 22, 30, 31, 32, 40, 41, 42, 50, 51, 52, 60, 62))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 1000
+
+		d, _ := NewTree[K, interface{}](8)
+		for i := 0; i < goroutines*perGoroutine; i++ {
+			d.Insert(k(i), i)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				// Delete the odd keys, while scanning and inserting beyond the
+				// original keys.
+				var batch []K
+				for i := 2*g + 1; i < goroutines*perGoroutine; i += 2 * goroutines {
+					if batch = append(batch, k(i)); len(batch) == 50 {
+						d.DeleteMany(batch)
+						batch = batch[:0]
+					}
+					d.Insert(k(goroutines*perGoroutine+i), i)
+				}
+				d.DeleteMany(batch)
+				for c := d.NewScanner(k(0)); c.Scan(); {
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		var expected []int
+		for i := 0; i < goroutines*perGoroutine; i += 2 {
+			expected = append(expected, i)
+		}
+		for i := 1; i < goroutines*perGoroutine; i += 2 {
+			expected = append(expected, goroutines*perGoroutine+i)
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+		if got, want := d.Len(), len(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestTreeMultiGet(t *testing.T) {
+	forEachKeyType(t, testTreeMultiGet[int32], testTreeMultiGet[int64], testTreeMultiGet[uint32], testTreeMultiGet[uint64], testTreeMultiGet[string])
+}
+
+func testTreeMultiGet[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureMultiGet := func(t *testing.T, d *Tree[K, interface{}], m map[int]bool, items ...int) {
+		t.Helper()
+
+		values, found := d.MultiGet(k.slice(items...))
+		if len(values) != len(items) || len(found) != len(items) {
+			// Fatalf may not be called from the concurrent sub-test.
+			t.Errorf("GOT: %v, %v; WANT: %v", len(values), len(found), len(items))
+			return
+		}
+		for i, item := range items {
+			if got, want := found[i], m[item]; got != want {
+				t.Errorf("found[%d] GOT: %v; WANT: %v", i, got, want)
+			}
+			var want interface{}
+			if m[item] {
+				want = item
+			}
+			if got := values[i]; got != want {
+				t.Errorf("values[%d] GOT: %v; WANT: %v", i, got, want)
+			}
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureMultiGet(t, d, nil)
+		ensureMultiGet(t, d, nil, 3, 1, 2)
+	})
+	t.Run("original order", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		m := make(map[int]bool)
+		for i := 0; i < 100; i += 2 {
+			d.Insert(k(i), i)
+			m[i] = true
+		}
+		// Keys are returned in the order requested, including duplicates and
+		// keys before, between, and after those in the tree.
+		ensureMultiGet(t, d, m, 42, 7, 0, 98, 42, 99, 13, 2, 2, 64)
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 1024
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8, 32} {
+			d, _ := NewTree[K, interface{}](order)
+			m := make(map[int]bool)
+			for i := 0; i < keyCount/2; i++ {
+				v := r.Intn(keyCount)
+				d.Insert(k(v), v)
+				m[v] = true
+			}
+
+			for step := 0; step < 20; step++ {
+				items := make([]int, r.Intn(300))
+				for i := range items {
+					items[i] = r.Intn(keyCount)
+				}
+				ensureMultiGet(t, d, m, items...)
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const keyCount = 4000
+
+		d, _ := NewTree[K, interface{}](8)
+		m := make(map[int]bool)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), i)
+			m[i] = true
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				if g%2 == 0 {
+					// Insert and delete odd keys, which are never looked up.
+					for i := g + 1; i < keyCount; i += 2 * goroutines {
+						d.Insert(k(i), i)
+						d.Delete(k(i))
+					}
+					return
+				}
+				r := rand.New(rand.NewSource(int64(g)))
+				for step := 0; step < 50; step++ {
+					items := make([]int, 100)
+					for i := range items {
+						items[i] = 2 * r.Intn(keyCount/2)
+					}
+					ensureMultiGet(t, d, m, items...)
+				}
+			}(g)
+		}
+		wg.Wait()
+	})
+}
+
+// BenchmarkInsertManyParallel compares inserting batches of keys one at a
+// time with inserting each batch with InsertMany, from many go-routines. The
+// keys of each batch are drawn from a narrow window of the key space, like the
+// timestamps or sequence numbers of an ingestion request.
+func BenchmarkInsertManyParallel(b *testing.B) {
+	const batchSize = 256
+	const window = 4 * batchSize
+
+	b.Run("Insert", func(b *testing.B) {
+		d, _ := NewTree[int, interface{}](64)
+		b.RunParallel(func(pb *testing.PB) {
+			r := rand.New(rand.NewSource(rand.Int63()))
+			for pb.Next() {
+				base := r.Intn(benchmarkItemCount)
+				for i := 0; i < batchSize; i++ {
+					d.Insert(base+r.Intn(window), nil)
+				}
+			}
+		})
+	})
+
+	b.Run("InsertMany", func(b *testing.B) {
+		d, _ := NewTree[int, interface{}](64)
+		b.RunParallel(func(pb *testing.PB) {
+			r := rand.New(rand.NewSource(rand.Int63()))
+			for pb.Next() {
+				base := r.Intn(benchmarkItemCount)
+				d.InsertMany(func(yield func(int, interface{}) bool) {
+					for i := 0; i < batchSize; i++ {
+						if !yield(base+r.Intn(window), nil) {
+							return
+						}
+					}
+				})
+			}
+		})
+	})
+}
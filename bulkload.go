@@ -0,0 +1,112 @@
+package gobptree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+)
+
+// NewTreeFromSorted returns a newly initialized Tree of the specified order,
+// whose keys are in ascending order, holding the key-value pairs yielded by
+// pairs. See NewTreeFuncFromSorted for how the tree is built.
+func NewTreeFromSorted[K cmp.Ordered, V any](order int, pairs iter.Seq2[K, V]) (*Tree[K, V], error) {
+	return NewTreeFuncFromSorted(order, cmp.Compare[K], pairs)
+}
+
+// NewTreeFuncFromSorted returns a newly initialized Tree of the specified
+// order, whose keys are ordered by compare, holding the key-value pairs
+// yielded by pairs. It returns an error when a key yielded by pairs is not
+// greater than the key yielded before it.
+//
+// Rather than inserting one key at a time, which splits each leaf node when it
+// fills and leaves it half full, the tree is built from the bottom up. Each
+// leaf node is filled to three quarters of the order of the tree, leaving room
+// for subsequent insertions before a node must be split, and then each level
+// of internal nodes is built above the level below it, until a single root
+// node remains.
+//
+//	// Index each name by its position in the slice.
+//	t, err := gobptree.NewTreeFromSorted(64, slices.All(names))
+func NewTreeFuncFromSorted[K any, V any](order int, compare func(a, b K) int, pairs iter.Seq2[K, V]) (*Tree[K, V], error) {
+	t, err := NewTreeFunc[K, V](order, compare)
+	if err != nil {
+		return nil, err
+	}
+	fill := max(order-order>>2, 2)
+
+	var leaves []node[K, V]
+	var tail *leafNode[K, V]
+	var count int64
+
+	for key, value := range pairs {
+		if tail != nil {
+			if t.compare(tail.runts[len(tail.runts)-1], key) >= 0 {
+				return nil, fmt.Errorf("cannot create tree when keys are not strictly increasing: %v follows %v", key, tail.runts[len(tail.runts)-1])
+			}
+		}
+		if tail == nil || len(tail.runts) == fill {
+			l := &leafNode[K, V]{
+				runts:  make([]K, 0, order),
+				values: make([]V, 0, order),
+			}
+			if tail != nil {
+				tail.next = l
+				l.prev.Store(tail)
+			}
+			leaves = append(leaves, l)
+			tail = l
+		}
+		tail.runts = append(tail.runts, key)
+		tail.values = append(tail.values, value)
+		count++
+	}
+	if count == 0 {
+		return t, nil
+	}
+
+	// The final leaf might hold as few as a single key-value pair, so share
+	// the pairs of the final two leaves evenly between them.
+	if len(leaves) > 1 {
+		left := leaves[len(leaves)-2].(*leafNode[K, V])
+		if moved := (len(left.runts) - len(tail.runts)) >> 1; moved > 0 {
+			from := len(left.runts) - moved
+			tail.runts = append(append(make([]K, 0, order), left.runts[from:]...), tail.runts...)
+			tail.values = append(append(make([]V, 0, order), left.values[from:]...), tail.values...)
+			clear(left.values[from:])
+			left.runts = left.runts[:from]
+			left.values = left.values[:from]
+		}
+	}
+
+	level := leaves
+	for len(level) > 1 {
+		level = buildInternalLevel(level, fill, order)
+	}
+	t.root = level[0]
+	t.length.Store(count)
+	return t, nil
+}
+
+// buildInternalLevel returns the internal nodes whose children are the nodes
+// from level, distributing the children as evenly as possible among the fewest
+// internal nodes that hold no more than fill children each.
+func buildInternalLevel[K any, V any](level []node[K, V], fill, order int) []node[K, V] {
+	parentCount := (len(level) + fill - 1) / fill
+	parents := make([]node[K, V], 0, parentCount)
+
+	for p := 0; p < parentCount; p++ {
+		// Children from level[lo:hi] become the children of this parent.
+		lo := p * len(level) / parentCount
+		hi := (p + 1) * len(level) / parentCount
+		parent := &internalNode[K, V]{
+			runts:    make([]K, 0, order),
+			children: make([]node[K, V], 0, order),
+		}
+		for _, child := range level[lo:hi] {
+			parent.runts = append(parent.runts, child.smallest())
+			parent.children = append(parent.children, child)
+		}
+		parents = append(parents, parent)
+	}
+	return parents
+}
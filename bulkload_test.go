@@ -0,0 +1,141 @@
+package gobptree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"testing"
+)
+
+func TestNewTreeFromSorted(t *testing.T) {
+	forEachKeyType(t, testNewTreeFromSorted[int32], testNewTreeFromSorted[int64], testNewTreeFromSorted[uint32], testNewTreeFromSorted[uint64], testNewTreeFromSorted[string])
+}
+
+func testNewTreeFromSorted[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	pairs := func(items ...int) iter.Seq2[K, interface{}] {
+		return func(yield func(K, interface{}) bool) {
+			for _, item := range items {
+				if !yield(k(item), k(item)) {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := NewTreeFromSorted[K, interface{}](3, pairs(1, 2, 3))
+		ensureError(t, err, "power of 2")
+	})
+	t.Run("empty input", func(t *testing.T) {
+		d, err := NewTreeFromSorted[K, interface{}](4, pairs())
+		ensureError(t, err)
+
+		ensureNode(t, d.root, node[K, interface{}](k.leafFrom(nil)))
+		if got, want := d.Len(), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("single leaf", func(t *testing.T) {
+		d, err := NewTreeFromSorted[K, interface{}](4, pairs(1, 2, 3))
+		ensureError(t, err)
+
+		ensureNode(t, d.root, node[K, interface{}](k.leafFrom(nil, 1, 2, 3)))
+	})
+	t.Run("fills leaves", func(t *testing.T) {
+		// Leaves of an order 4 tree are filled with 3 keys, and the final two
+		// leaves share their keys evenly rather than leaving a single key in
+		// the final leaf.
+		d, err := NewTreeFromSorted[K, interface{}](4, pairs(1, 2, 3, 4, 5, 6, 7))
+		ensureError(t, err)
+
+		root, ok := d.root.(*internalNode[K, interface{}])
+		if !ok {
+			t.Fatalf("GOT: %T; WANT: %T", d.root, root)
+		}
+		expected := [][]int{{1, 2, 3}, {4, 5}, {6, 7}}
+		if got, want := len(root.children), len(expected); got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+		for i, items := range expected {
+			l := root.children[i].(*leafNode[K, interface{}])
+			if got, want := root.runts[i], k(items[0]); got != want {
+				t.Errorf("runts[%d] GOT: %v; WANT: %v", i, got, want)
+			}
+			if got, want := fmt.Sprint(l.runts), fmt.Sprint(k.slice(items...)); got != want {
+				t.Errorf("children[%d] GOT: %v; WANT: %v", i, got, want)
+			}
+		}
+	})
+	t.Run("keys not strictly increasing", func(t *testing.T) {
+		t.Run("duplicate", func(t *testing.T) {
+			_, err := NewTreeFromSorted[K, interface{}](4, pairs(1, 2, 3, 3, 4))
+			ensureError(t, err, "not strictly increasing")
+		})
+		t.Run("decreasing", func(t *testing.T) {
+			_, err := NewTreeFromSorted[K, interface{}](4, pairs(1, 2, 3, 4, 5, 6, 7, 8, 9, 2))
+			ensureError(t, err, "not strictly increasing")
+		})
+	})
+	t.Run("every size", func(t *testing.T) {
+		for _, order := range []int{2, 4, 8, 16} {
+			var keys []int
+			for count := 0; count <= 300; count++ {
+				d, err := NewTreeFromSorted[K, interface{}](order, pairs(keys...))
+				ensureError(t, err)
+
+				reversed := make([]int, len(keys))
+				for i, v := range keys {
+					reversed[len(keys)-1-i] = v
+				}
+				ensureScan(t, d, k(0), k.slice(keys...))
+				ensureReverseScan(t, d, k(1<<20), k.slice(reversed...))
+				ensureLeafLinks(t, d)
+				ensureStructure(t, d)
+				if got, want := d.Len(), count; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				l := d.lockLeftmostLeaf()
+				l.unlock()
+				fill := max(order-order>>2, 2)
+				for ; l != nil; l = l.next {
+					if got := len(l.runts); got > fill || (count > fill && got < fill>>1) {
+						t.Errorf("GOT: %v; WANT: between %v and %v", got, fill>>1, fill)
+					}
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; COUNT: %d", order, count)
+				}
+
+				keys = append(keys, 2*(count+1))
+			}
+		}
+	})
+	t.Run("remains usable", func(t *testing.T) {
+		var keys []int
+		for i := 0; i < 100; i++ {
+			keys = append(keys, 2*i)
+		}
+		d, err := NewTreeFromSorted[K, interface{}](4, pairs(keys...))
+		ensureError(t, err)
+
+		for i := 0; i < 100; i++ {
+			d.Insert(k(2*i+1), 2*i+1)
+		}
+		for i := 0; i < 200; i += 4 {
+			d.Delete(k(i))
+		}
+
+		var expected []int
+		for i := 0; i < 200; i++ {
+			if i%4 != 0 {
+				expected = append(expected, i)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+		if got, want := d.Len(), len(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
@@ -0,0 +1,321 @@
+package gobptree
+
+import (
+	"iter"
+	"slices"
+	"sync/atomic"
+)
+
+// cowContext identifies the tree that may modify a node in place. A node whose
+// context is not the context of the tree that reaches it is shared with a
+// clone, snapshot, or version of the tree, and is never modified again. Such a
+// node is copied, and the copy takes its place in the tree, before it is
+// modified.
+type cowContext struct {
+	// the context of the tree that adopted the nodes of this context once the
+	// version sharing them was released
+	adopter atomic.Pointer[cowContext]
+}
+
+// current returns the context of the tree that may modify the nodes of this
+// context in place, following the contexts that adopted them.
+func (c *cowContext) current() *cowContext {
+	if c == nil {
+		return nil
+	}
+	adopter := c.adopter.Load()
+	if adopter == nil {
+		return c
+	}
+	current := adopter.current()
+	if current != adopter {
+		// Each context is adopted at most once, so the nodes of this context
+		// may skip the contexts in between from now on.
+		c.adopter.Store(current)
+	}
+	return current
+}
+
+func (i *internalNode[K, V]) clone(cow *cowContext) node[K, V] {
+	return &internalNode[K, V]{
+		runts:      slices.Clone(i.runts),
+		children:   slices.Clone(i.children),
+		counts:     slices.Clone(i.counts),
+		summaries:  slices.Clone(i.summaries),
+		aggregator: i.aggregator,
+		cow:        cow,
+	}
+}
+
+func (i *internalNode[K, V]) owner() *cowContext { return i.cow }
+
+// mutableChild returns the child at index, first replacing it with a copy when
+// it is shared with a clone or snapshot of the tree, so the child may be
+// modified. This node must be locked, and must not itself be shared.
+func (i *internalNode[K, V]) mutableChild(index int) node[K, V] {
+	child := i.children[index]
+	if cow := i.cow.current(); child.owner().current() != cow {
+		// A shared node is never modified, so it may be copied without
+		// acquiring its lock.
+		child = child.clone(cow)
+		i.children[index] = child
+	}
+	return child
+}
+
+// clone returns a copy of this leaf. The copy is not linked to any other leaf,
+// because only leaves that belong to no context are linked.
+func (l *leafNode[K, V]) clone(cow *cowContext) node[K, V] {
+	return &leafNode[K, V]{
+		runts:  slices.Clone(l.runts),
+		values: slices.Clone(l.values),
+		cow:    cow,
+	}
+}
+
+func (l *leafNode[K, V]) owner() *cowContext { return l.cow }
+
+// mutableRoot returns the locked root node n, first replacing it with a locked
+// copy when it is shared with a clone or snapshot of the tree, so the root may
+// be modified. The caller must hold the tree's write mutex.
+func (t *Tree[K, V]) mutableRoot(n node[K, V]) node[K, V] {
+	cow := t.cow.Load()
+	if n.owner().current() == cow {
+		return n
+	}
+	c := n.clone(cow)
+	// Operations waiting for the lock on the former root will find it is no
+	// longer the root, and start over.
+	c.lock()
+	t.setRoot(c)
+	n.unlock()
+	return c
+}
+
+// Clone returns a copy of the tree, which shares every node with the tree
+// until either of them modifies the node. It waits for every operation that is
+// modifying the tree to complete, so the copy holds every key-value pair in
+// the tree at a single point in time, and is independent of the tree
+// afterwards. Each node is copied by the first operation that modifies it in
+// either tree, along with its ancestors, so the cost of cloning is spread over
+// the modifications that follow.
+//
+// The leaf nodes of a tree are linked with each other to allow enumeration, but
+// a leaf shared by two trees cannot link to the next leaf of both of them. So
+// once a tree has been cloned, cursors move from each leaf to the next by
+// releasing the leaf and searching for the key after it, like the cursor
+// returned by NewBufferedScanner, rather than holding the lock on the leaf
+// until the next leaf is locked.
+func (t *Tree[K, V]) Clone() *Tree[K, V] {
+	c := t.freeze()
+	c.cow.Store(&cowContext{})
+	return c
+}
+
+// freeze returns a tree that shares the nodes of this tree, after waiting for
+// every operation modifying this tree to complete.
+func (t *Tree[K, V]) freeze() *Tree[K, V] {
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+	c := t.frozen()
+	// Neither tree knows when the other no longer shares its nodes, so
+	// neither links its leaves again.
+	t.cloned.Store(true)
+	c.cloned.Store(true)
+	return c
+}
+
+// frozen returns a tree that shares the nodes of this tree, whose context is
+// the context of this tree. Every node in both trees is shared from then on,
+// because this tree modifies nodes of a new context. The caller must hold the
+// tree's write mutex exclusively.
+func (t *Tree[K, V]) frozen() *Tree[K, V] {
+	t.rootMutex.RLock()
+	root := t.root
+	t.rootMutex.RUnlock()
+
+	c := &Tree[K, V]{
+		root:       root,
+		compare:    t.compare,
+		order:      t.order,
+		counted:    t.counted,
+		aggregator: t.aggregator,
+	}
+	c.length.Store(t.length.Load())
+	c.cow.Store(t.cow.Load())
+	t.cow.Store(&cowContext{})
+	return c
+}
+
+// maybeRelink links the leaves of the tree again when the tree shares its nodes
+// with neither a clone, a snapshot, a version, nor the tree as it was before a
+// batch, which only cursors search. When an operation holds the write mutex,
+// the next operation to modify the tree links them instead.
+func (t *Tree[K, V]) maybeRelink() {
+	if !t.mayRelink() {
+		return
+	}
+	if !t.writeMutex.TryLock() {
+		t.relinkPending.Store(true)
+		return
+	}
+	t.relink()
+	t.writeMutex.Unlock()
+}
+
+// mayRelink returns true when the leaves of the tree are not linked, but no
+// other tree, version, or cursor might observe them being linked.
+func (t *Tree[K, V]) mayRelink() bool {
+	return t.cow.Load() != nil && !t.cloned.Load() && t.versions.Load() == 0 && t.cursors.Load() == 0
+}
+
+// relink links the leaves of the tree again, and returns every node of the
+// tree to no context, so the tree modifies the links of its leaves in place
+// once more. Only the nodes copied since the leaves were last linked are
+// visited, because the leaves below a node that belongs to no context are
+// still linked with one another. The caller must hold the tree's write mutex
+// exclusively.
+func (t *Tree[K, V]) relink() {
+	t.relinkPending.Store(false)
+	if !t.mayRelink() {
+		return
+	}
+
+	// No operation modifies the tree while the write mutex is held
+	// exclusively, so nodes may be read without acquiring their locks. A
+	// cursor created from now on finds the leaves not linked until the
+	// context of the tree is cleared, so it neither follows the links, nor
+	// needs to release its leaf for them to be modified.
+	var tail *leafNode[K, V]
+	link := func(first, last *leafNode[K, V]) {
+		first.prev.Store(tail)
+		if tail != nil {
+			tail.next = first
+		}
+		tail = last
+	}
+	var walk func(n node[K, V])
+	walk = func(n node[K, V]) {
+		switch x := n.(type) {
+		case *internalNode[K, V]:
+			if x.cow == nil {
+				link(leftmostLeaf(n), rightmostLeaf(n))
+				return
+			}
+			x.cow = nil
+			for _, child := range x.children {
+				walk(child)
+			}
+		case *leafNode[K, V]:
+			x.cow = nil
+			link(x, x)
+		}
+	}
+	walk(t.root)
+	if tail != nil {
+		tail.next = nil
+	}
+	t.cow.Store(nil)
+}
+
+// leftmostLeaf returns the first leaf below n.
+func leftmostLeaf[K any, V any](n node[K, V]) *leafNode[K, V] {
+	for {
+		i, ok := n.(*internalNode[K, V])
+		if !ok {
+			return n.(*leafNode[K, V])
+		}
+		n = i.children[0]
+	}
+}
+
+// rightmostLeaf returns the final leaf below n.
+func rightmostLeaf[K any, V any](n node[K, V]) *leafNode[K, V] {
+	for {
+		i, ok := n.(*internalNode[K, V])
+		if !ok {
+			return n.(*leafNode[K, V])
+		}
+		n = i.children[len(i.children)-1]
+	}
+}
+
+// Snapshot is a read-only view of a tree at a single point in time. It shares
+// every node with the tree it was taken from, until the tree modifies the node.
+type Snapshot[K any, V any] struct {
+	t *Tree[K, V]
+}
+
+// Snapshot returns a read-only view of the tree that holds every key-value
+// pair in the tree at a single point in time, and which is unaffected by the
+// modifications of the tree afterwards. Like Clone, it waits for every
+// operation that is modifying the tree to complete, and nodes are only copied
+// when the tree later modifies them.
+//
+//	s := t.Snapshot()
+//	for k, v := range s.All() {
+//	    report(k, v)
+//	}
+func (t *Tree[K, V]) Snapshot() *Snapshot[K, V] {
+	s := t.freeze()
+	// Nothing modifies the nodes of the snapshot, but a context distinct from
+	// the context of its nodes marks its leaves as not linked.
+	s.cow.Store(&cowContext{})
+	return &Snapshot[K, V]{t: s}
+}
+
+// Clone returns a tree that holds every key-value pair in the snapshot, and
+// which may be modified without affecting the snapshot.
+func (s *Snapshot[K, V]) Clone() *Tree[K, V] {
+	return s.t.Clone()
+}
+
+// Len returns the number of key-value pairs in the snapshot.
+func (s *Snapshot[K, V]) Len() int { return s.t.Len() }
+
+// Search returns the value associated with key from the snapshot.
+func (s *Snapshot[K, V]) Search(key K) (V, bool) { return s.t.Search(key) }
+
+// Min returns the smallest key in the snapshot along with its value, and false
+// when the snapshot is empty.
+func (s *Snapshot[K, V]) Min() (K, V, bool) { return s.t.Min() }
+
+// Max returns the largest key in the snapshot along with its value, and false
+// when the snapshot is empty.
+func (s *Snapshot[K, V]) Max() (K, V, bool) { return s.t.Max() }
+
+// NewScanner returns a cursor that iteratively returns key-value pairs from the
+// snapshot in ascending order starting at key. See Tree.NewScanner.
+func (s *Snapshot[K, V]) NewScanner(key K) *Cursor[K, V] { return s.t.NewScanner(key) }
+
+// NewReverseScanner returns a cursor that iteratively returns key-value pairs
+// from the snapshot in descending order starting at key. See
+// Tree.NewReverseScanner.
+func (s *Snapshot[K, V]) NewReverseScanner(key K) *Cursor[K, V] {
+	return s.t.NewReverseScanner(key)
+}
+
+// NewRangeScanner returns a cursor that iteratively returns key-value pairs
+// from the snapshot in ascending order, starting at lo and ending at hi. See
+// Tree.NewRangeScanner.
+func (s *Snapshot[K, V]) NewRangeScanner(lo, hi K, opts RangeOptions) *Cursor[K, V] {
+	return s.t.NewRangeScanner(lo, hi, opts)
+}
+
+// All returns an iterator over every key-value pair in the snapshot in
+// ascending order.
+func (s *Snapshot[K, V]) All() iter.Seq2[K, V] { return s.t.All() }
+
+// Ascend returns an iterator over the key-value pairs in the snapshot in
+// ascending order, starting at key.
+func (s *Snapshot[K, V]) Ascend(key K) iter.Seq2[K, V] { return s.t.Ascend(key) }
+
+// Descend returns an iterator over the key-value pairs in the snapshot in
+// descending order, starting at key.
+func (s *Snapshot[K, V]) Descend(key K) iter.Seq2[K, V] { return s.t.Descend(key) }
+
+// Range returns an iterator over the key-value pairs in the snapshot in
+// ascending order, starting at lo and ending at hi.
+func (s *Snapshot[K, V]) Range(lo, hi K, opts RangeOptions) iter.Seq2[K, V] {
+	return s.t.Range(lo, hi, opts)
+}
@@ -0,0 +1,282 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestTreeClone(t *testing.T) {
+	forEachKeyType(t, testTreeClone[int32], testTreeClone[int64], testTreeClone[uint32], testTreeClone[uint64], testTreeClone[string])
+}
+
+func testTreeClone[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const keyCount = 200
+
+	// mutate applies a random modification to the tree, and the same
+	// modification to the map of the pairs expected in the tree.
+	mutate := func(r *rand.Rand, d *Tree[K, interface{}], m map[int]int) {
+		v := r.Intn(keyCount)
+		value := r.Intn(1000)
+		switch r.Intn(8) {
+		case 0:
+			d.Delete(k(v))
+			delete(m, v)
+		case 1:
+			d.Update(k(v), func(interface{}, bool) interface{} { return value })
+			m[v] = value
+		case 2:
+			if old, ok := m[v]; ok {
+				d.CompareAndSwap(k(v), old, value)
+				m[v] = value
+			}
+		case 3:
+			d.Compute(k(v), func(interface{}, bool) (interface{}, ComputeAction) { return nil, ComputeDelete })
+			delete(m, v)
+		case 4:
+			hi := v + r.Intn(30)
+			d.DeleteRange(k(v), k(hi))
+			for i := v; i <= hi; i++ {
+				delete(m, i)
+			}
+		case 5:
+			keys := make([]K, 10)
+			for i := range keys {
+				key := r.Intn(keyCount)
+				keys[i] = k(key)
+				delete(m, key)
+			}
+			d.DeleteMany(keys)
+		case 6:
+			items := make([]int, 30)
+			for i := range items {
+				items[i] = r.Intn(keyCount)
+				m[items[i]] = value
+			}
+			d.InsertMany(func(yield func(K, interface{}) bool) {
+				for _, item := range items {
+					if !yield(k(item), value) {
+						return
+					}
+				}
+			})
+		default:
+			d.Insert(k(v), value)
+			m[v] = value
+		}
+	}
+
+	// ensurePairs ensures the tree holds exactly the pairs of the map,
+	// enumerating them in both directions.
+	ensurePairs := func(t *testing.T, d *Tree[K, interface{}], m map[int]int) {
+		t.Helper()
+		var expected, reversed []int
+		for i := 0; i < keyCount+30; i++ {
+			value, ok := m[i]
+			if ok {
+				expected = append(expected, i)
+				reversed = append([]int{i}, reversed...)
+			}
+			if got, found := d.Search(k(i)); found != ok || (ok && got != value) {
+				t.Errorf("Search(%v) GOT: %v, %v; WANT: %v, %v", i, got, found, value, ok)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureReverseScan(t, d, k(keyCount+30), k.slice(reversed...))
+		if got, want := d.Len(), len(m); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureStructure(t, d)
+	}
+
+	newTree := func(order int, m map[int]int) *Tree[K, interface{}] {
+		d, _ := NewTree[K, interface{}](order)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), i)
+			m[i] = i
+		}
+		return d
+	}
+
+	t.Run("snapshot is unaffected by the tree", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			m := make(map[int]int)
+			d := newTree(order, m)
+
+			s := d.Snapshot()
+			frozen := make(map[int]int)
+			for key, value := range m {
+				frozen[key] = value
+			}
+
+			for step := 0; step < 300; step++ {
+				mutate(r, d, m)
+				if step%30 == 0 {
+					ensurePairs(t, d, m)
+					ensurePairs(t, s.t, frozen)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+
+			var count int
+			for key, value := range s.Range(k(10), k(19), RangeOptions{ExcludeHi: true}) {
+				if want := frozen[count*2+10]; key != k(count*2+10) || value != want {
+					t.Errorf("GOT: %v, %v; WANT: %v, %v", key, value, k(count*2+10), want)
+				}
+				count++
+			}
+			if got, want := count, 5; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		}
+	})
+	t.Run("clone and tree are independent", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			m := make(map[int]int)
+			d := newTree(order, m)
+
+			c := d.Clone()
+			mc := make(map[int]int)
+			for key, value := range m {
+				mc[key] = value
+			}
+
+			for step := 0; step < 300; step++ {
+				mutate(r, d, m)
+				mutate(r, c, mc)
+				if step%30 == 0 {
+					ensurePairs(t, d, m)
+					ensurePairs(t, c, mc)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("clone maintains counts and summaries", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		counted, _ := NewOrderStatisticTree[K, interface{}](4)
+		aggregated, _ := NewAggregateTree[K, interface{}](4, sumAggregator)
+		for _, d := range []*Tree[K, interface{}]{counted, aggregated} {
+			m := make(map[int]int)
+			for i := 0; i < keyCount; i++ {
+				d.Insert(k(i), i)
+				m[i] = i
+			}
+			c := d.Clone()
+			mc := make(map[int]int)
+			for key, value := range m {
+				mc[key] = value
+			}
+			for step := 0; step < 200; step++ {
+				mutate(r, d, m)
+				mutate(r, c, mc)
+			}
+			for _, tree := range []*Tree[K, interface{}]{d, c} {
+				if tree.counted {
+					ensureCounts(t, tree)
+				}
+				if tree.aggregator != nil {
+					ensureSummaries(t, tree)
+				}
+			}
+			ensurePairs(t, d, m)
+			ensurePairs(t, c, mc)
+		}
+	})
+	t.Run("shares unmodified nodes", func(t *testing.T) {
+		m := make(map[int]int)
+		d := newTree(4, m)
+		c := d.Clone()
+
+		// nodes collects every node of a tree.
+		nodes := func(d *Tree[K, interface{}]) map[node[K, interface{}]]bool {
+			all := make(map[node[K, interface{}]]bool)
+			var walk func(n node[K, interface{}])
+			walk = func(n node[K, interface{}]) {
+				all[n] = true
+				if i, ok := n.(*internalNode[K, interface{}]); ok {
+					for _, child := range i.children {
+						walk(child)
+					}
+				}
+			}
+			walk(d.root)
+			return all
+		}
+
+		d.Insert(k(1), 1)
+
+		// Only the nodes along the path to the modified leaf were copied.
+		var height int
+		for n := d.root; ; height++ {
+			i, ok := n.(*internalNode[K, interface{}])
+			if !ok {
+				break
+			}
+			n = i.children[0]
+		}
+		cloned := nodes(c)
+		var copied int
+		for n := range nodes(d) {
+			if !cloned[n] {
+				copied++
+			}
+		}
+		if got, want := copied, height+1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if _, ok := c.Search(k(1)); ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, false)
+		}
+	})
+	t.Run("snapshot is a single point in time", func(t *testing.T) {
+		const total = 2000
+
+		d, _ := NewTree[K, interface{}](4)
+
+		var wg sync.WaitGroup
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			// Each key is inserted after every key before it.
+			for i := 0; i < total; i++ {
+				d.Insert(k(i), i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 50; n++ {
+				s := d.Snapshot()
+				// The snapshot holds the keys inserted before it was taken,
+				// which are a prefix of the keys.
+				var count int
+				for key := range s.All() {
+					if key != k(count) {
+						t.Errorf("GOT: %v; WANT: %v", key, k(count))
+						return
+					}
+					count++
+				}
+				if got, want := s.Len(), count; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+			}
+		}()
+		wg.Wait()
+
+		ensureScan(t, d, k(0), k.slice(func() []int {
+			expected := make([]int, total)
+			for i := range expected {
+				expected[i] = i
+			}
+			return expected
+		}()...))
+	})
+}
@@ -2,7 +2,9 @@ package gobptree
 
 import (
 	"fmt"
+	"iter"
 	"strconv"
+	"strings"
 	"testing"
 )
 
@@ -17,275 +19,41 @@ func (a testString) Less(b interface{}) bool {
 
 func (_ testString) ZeroValue() Comparable { return testString("") }
 
-////////////////////////////////////////
+// otherString is a Comparable data structure of a type other than testString,
+// used to ensure keys of different types are detected.
+type otherString string
 
-func ensureComparableLeaves(t *testing.T, a comparableNode, leafB *comparableLeafNode) {
-	t.Helper()
-	if a == nil {
-		t.Fatalf("GOT: %v; WANT: %#v", a, leafB)
-	}
-	leafA, ok := a.(*comparableLeafNode)
-	if !ok {
-		t.Fatalf("GOT: %v; WANT: %#v", a, leafB)
-	}
-	if got, want := len(leafA.runts), len(leafB.runts); got != want {
-		t.Errorf("length(runts) GOT: %v; WANT: %v", got, want)
-	}
-	if got, want := len(leafA.values), len(leafB.values); got != want {
-		t.Errorf("length(values) GOT: %v; WANT: %v", got, want)
-	}
-	for i := 0; i < len(leafA.runts) && i < len(leafB.runts); i++ {
-		if got, want := leafA.runts[i], leafB.runts[i]; got != want {
-			t.Errorf("runt[%d] GOT: %v; WANT: %v", i, got, want)
-		}
-		if got, want := leafA.values[i], leafB.values[i]; got != want {
-			t.Errorf("value[%d] GOT: %v; WANT: %v", i, got, want)
-		}
-	}
-	if got, want := leafA.next, leafB.next; got != want {
-		t.Errorf("next GOT: %v; WANT: %v", got, want)
-	}
-	if t.Failed() {
-		t.Errorf("\nGOT:\n\t%#v\nWANT:\n\t%#v", a, leafB)
-	}
+func (a otherString) Less(b interface{}) bool {
+	bs, ok := b.(otherString)
+	return ok && string(a) < string(bs)
 }
 
-// cls returns a slice of Comparable String values from its arguments.
-func cls(items ...string) []Comparable {
-	bar := make([]Comparable, len(items))
-	for i := 0; i < len(items); i++ {
-		bar[i] = testString(items[i])
-	}
-	return bar
+func (_ otherString) ZeroValue() Comparable { return otherString("") }
+
+// testComparer is a Comparer data structure used for testing trees created by
+// NewComparerTree.
+type testComparer string
+
+func (a testComparer) Compare(b testComparer) int {
+	return strings.Compare(string(a), string(b))
 }
 
-func TestComparableBinarySearch(t *testing.T) {
-	t.Run("skip values", func(t *testing.T) {
-		values := cls("b", "d", "f", "h", "j", "l", "n", "p", "r", "t", "v", "x")
+////////////////////////////////////////
 
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("a"), values), 0; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("b"), values), 0; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("c"), values), 1; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("d"), values), 1; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("e"), values), 2; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("f"), values), 2; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("g"), values), 3; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("h"), values), 3; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("i"), values), 4; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("j"), values), 4; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("k"), values), 5; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("l"), values), 5; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("m"), values), 6; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("n"), values), 6; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("o"), values), 7; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("p"), values), 7; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("q"), values), 8; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("r"), values), 8; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("s"), values), 9; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("t"), values), 9; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("u"), values), 10; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("v"), values), 10; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("w"), values), 11; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("x"), values), 11; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("y"), values), 11; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
-		}
-		if got, want := comparableSearchGreaterThanOrEqualTo(testString("z"), values), 11; got != want {
-			t.Errorf("GOT: %v; WANT: %v", got, want)
+func TestCompareComparable(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"a", "b", -1},
+		{"b", "b", 0},
+		{"c", "b", 1},
+	}
+	for _, c := range cases {
+		if got, want := compareComparable(testString(c.a), testString(c.b)), c.want; got != want {
+			t.Errorf("CASE: %q, %q; GOT: %v; WANT: %v", c.a, c.b, got, want)
 		}
-	})
-	t.Run("greater than or equal to", func(t *testing.T) {
-		t.Run("empty list", func(t *testing.T) {
-			i := comparableSearchGreaterThanOrEqualTo(testString("A"), nil)
-			if got, want := i, 0; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		})
-		t.Run("single item list", func(t *testing.T) {
-			t.Run("key before", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("A"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("B"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key after", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("C"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-		})
-		t.Run("multiple item list", func(t *testing.T) {
-			t.Run("key before first", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("A"), cls("B", "D", "F"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match first", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("B"), cls("B", "D", "F"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key between first and second", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("C"), cls("B", "D", "F"))
-				if got, want := i, 1; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match second", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("D"), cls("B", "D", "F"))
-				if got, want := i, 1; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key between second and third", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("E"), cls("B", "D", "F"))
-				if got, want := i, 2; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match third", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("F"), cls("B", "D", "F"))
-				if got, want := i, 2; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key after third", func(t *testing.T) {
-				i := comparableSearchGreaterThanOrEqualTo(testString("G"), cls("B", "D", "F"))
-				if got, want := i, 2; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-		})
-	})
-	t.Run("less than or equal to", func(t *testing.T) {
-		t.Run("empty list", func(t *testing.T) {
-			i := comparableSearchLessThanOrEqualTo(testString("A"), cls())
-			if got, want := i, 0; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		})
-		t.Run("single item list", func(t *testing.T) {
-			t.Run("key before", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("A"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("B"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key after", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("C"), cls("B"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-		})
-		t.Run("multiple item list", func(t *testing.T) {
-			t.Run("key before first", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("A"), cls("B", "D", "F"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match first", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("B"), cls("B", "D", "F"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key between first and second", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("C"), cls("B", "D", "F"))
-				if got, want := i, 0; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match second", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("D"), cls("B", "D", "F"))
-				if got, want := i, 1; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key between second and third", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("E"), cls("B", "D", "F"))
-				if got, want := i, 1; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key match third", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("F"), cls("B", "D", "F"))
-				if got, want := i, 2; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-			t.Run("key after third", func(t *testing.T) {
-				i := comparableSearchLessThanOrEqualTo(testString("G"), cls("B", "D", "F"))
-				if got, want := i, 2; got != want {
-					t.Fatalf("GOT: %v; WANT: %v", got, want)
-				}
-			})
-		})
-	})
+	}
 }
 
 func TestNewComparableTreeReturnsErrorWhenInvalidOrder(t *testing.T) {
@@ -297,369 +65,6 @@ func TestNewComparableTreeReturnsErrorWhenInvalidOrder(t *testing.T) {
 	}
 }
 
-func TestComparableInternalNodeMaybeSplit(t *testing.T) {
-	leafD := &comparableLeafNode{
-		runts:  cls("d", "dd", "ddd"),
-		values: []interface{}{1, 2, 3},
-	}
-	leafC := &comparableLeafNode{
-		runts:  cls("c", "cc", "ccc"),
-		values: []interface{}{1, 2, 3},
-		next:   leafD,
-	}
-	leafB := &comparableLeafNode{
-		runts:  cls("b", "bb", "bbb"),
-		values: []interface{}{1, 2, 3},
-		next:   leafC,
-	}
-	leafA := &comparableLeafNode{
-		runts:  cls("a", "aa", "aaa"),
-		values: []interface{}{1, 2, 3},
-		next:   leafB,
-	}
-
-	ni := &comparableInternalNode{
-		runts:    cls("a", "b", "c", "d"),
-		children: []comparableNode{leafA, leafB, leafC, leafD},
-	}
-
-	t.Run("does nothing when not full", func(t *testing.T) {
-		_, right := ni.maybeSplit(6)
-		if right != nil {
-			t.Errorf("GOT: %v; WANT: %v", right, nil)
-		}
-	})
-
-	t.Run("splits when full", func(t *testing.T) {
-		expectedLeft := &comparableInternalNode{
-			runts:    cls("a", "b"),
-			children: []comparableNode{leafA, leafB},
-		}
-		expectedRight := &comparableInternalNode{
-			runts:    cls("c", "d"),
-			children: []comparableNode{leafC, leafD},
-		}
-
-		leftNode, rightNode := ni.maybeSplit(4)
-
-		// left side
-		if leftNode == nil {
-			t.Fatalf("GOT: %v; WANT: %v", leftNode, "some node")
-		}
-		left, ok := leftNode.(*comparableInternalNode)
-		if !ok {
-			t.Fatalf("GOT: %v; WANT: %v", ok, true)
-		}
-		if got, want := len(left.runts), len(expectedLeft.runts); got != want {
-			t.Fatalf("GOT: %v; WANT: %v", got, want)
-		}
-		for i := 0; i < len(left.runts); i++ {
-			if got, want := left.runts[i], expectedLeft.runts[i]; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		}
-		if got, want := len(left.children), len(expectedLeft.children); got != want {
-			t.Fatalf("GOT: %v; WANT: %v", got, want)
-		}
-		for i := 0; i < len(left.children); i++ {
-			if got, want := left.children[i], expectedLeft.children[i]; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		}
-
-		// right side
-		if rightNode == nil {
-			t.Fatalf("GOT: %v; WANT: %v", rightNode, "some node")
-		}
-		right, ok := rightNode.(*comparableInternalNode)
-		if !ok {
-			t.Fatalf("GOT: %v; WANT: %v", ok, true)
-		}
-		if got, want := len(right.runts), len(expectedRight.runts); got != want {
-			t.Fatalf("GOT: %v; WANT: %v", got, want)
-		}
-		for i := 0; i < len(right.runts); i++ {
-			if got, want := right.runts[i], expectedRight.runts[i]; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		}
-		if got, want := len(right.children), len(expectedRight.children); got != want {
-			t.Fatalf("GOT: %v; WANT: %v", got, want)
-		}
-		for i := 0; i < len(right.children); i++ {
-			if got, want := right.children[i], expectedRight.children[i]; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-		}
-	})
-}
-
-func TestInternalComparableNodeInsertSmallerKey(t *testing.T) {
-	gimme := func() (*comparableLeafNode, *comparableLeafNode) {
-		leafB := &comparableLeafNode{
-			runts:  cls("b", "bb"),
-			values: []interface{}{1, 2},
-		}
-		leafA := &comparableLeafNode{
-			runts:  cls("aa", "aaa"),
-			values: []interface{}{2, 3},
-			next:   leafB,
-		}
-		return leafA, leafB
-	}
-
-	leafA, leafB := gimme()
-	ni := &comparableInternalNode{
-		runts:    cls("aa", "b"),
-		children: []comparableNode{leafA, leafB},
-	}
-
-	d := &ComparableTree{root: ni, order: 4}
-
-	d.Insert(testString("a"), 1)
-
-	if got, want := string(ni.runts[0].(testString)), "a"; got != want {
-		t.Fatalf("GOT: %v; WANT: %v", got, want)
-	}
-}
-
-func TestComparableLeafNodeMaybeSplit(t *testing.T) {
-	gimme := func() (*comparableLeafNode, *comparableLeafNode) {
-		leafB := &comparableLeafNode{
-			runts:  cls("b", "bb", "bbb", "bbbb"),
-			values: []interface{}{1, 2, 3, 4},
-		}
-		leafA := &comparableLeafNode{
-			runts:  cls("a", "aa", "aaa", "aaaa"),
-			values: []interface{}{1, 2, 3, 4},
-			next:   leafB,
-		}
-		return leafA, leafB
-	}
-
-	t.Run("when not full does nothing", func(t *testing.T) {
-		_, leafB := gimme()
-		_, right := leafB.maybeSplit(6)
-		if right != nil {
-			t.Errorf("GOT: %v; WANT: %v", right, nil)
-		}
-	})
-
-	t.Run("splits non-right edge when full", func(t *testing.T) {
-		leafA, leafB := gimme()
-		leftNode, rightNode := leafA.maybeSplit(4)
-		ensureComparableLeaves(t, leftNode, &comparableLeafNode{
-			runts:  cls("a", "aa"),
-			values: []interface{}{1, 2},
-			next:   rightNode.(*comparableLeafNode),
-		})
-		ensureComparableLeaves(t, rightNode, &comparableLeafNode{
-			runts:  cls("aaa", "aaaa"),
-			values: []interface{}{3, 4},
-			next:   leafB,
-		})
-	})
-	t.Run("splits right edge when full", func(t *testing.T) {
-		leafA, leafB := gimme()
-		leftNode, rightNode := leafB.maybeSplit(4)
-		if got, want := leafA.next, leftNode; got != want {
-			t.Fatalf("GOT: %v; WANT: %v", got, want)
-		}
-		ensureComparableLeaves(t, leftNode, &comparableLeafNode{
-			runts:  cls("b", "bb"),
-			values: []interface{}{1, 2},
-			next:   rightNode.(*comparableLeafNode),
-		})
-		ensureComparableLeaves(t, rightNode, &comparableLeafNode{
-			runts:  cls("bbb", "bbbb"),
-			values: []interface{}{3, 4},
-			next:   nil,
-		})
-	})
-}
-
-func TestInsertIntoSingleLeafComparableTree(t *testing.T) {
-	t.Run("when fewer than order elements", func(t *testing.T) {
-		t.Run("when empty", func(t *testing.T) {
-			d, _ := NewComparableTree(4)
-			ln, ok := d.root.(*comparableLeafNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, false)
-			}
-			d.Insert(testString("30"), "thirty")
-			ensureComparableLeaves(t, ln, &comparableLeafNode{
-				runts:  cls("30"),
-				values: []interface{}{"thirty"},
-			})
-		})
-		t.Run("when less than first runt", func(t *testing.T) {
-			d, _ := NewComparableTree(4)
-			ln, ok := d.root.(*comparableLeafNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, false)
-			}
-			d.Insert(testString("30"), "thirty")
-			d.Insert(testString("10"), "ten")
-			ensureComparableLeaves(t, ln, &comparableLeafNode{
-				runts:  cls("10", "30"),
-				values: []interface{}{"ten", "thirty"},
-			})
-		})
-		t.Run("when update value", func(t *testing.T) {
-			d, _ := NewComparableTree(4)
-			ln, ok := d.root.(*comparableLeafNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, false)
-			}
-			d.Insert(testString("30"), "thirty")
-			d.Insert(testString("10"), "ten")
-			d.Insert(testString("30"), "THIRTY")
-			ensureComparableLeaves(t, ln, &comparableLeafNode{
-				runts:  cls("10", "30"),
-				values: []interface{}{"ten", "THIRTY"},
-			})
-		})
-		t.Run("when between first and final runt", func(t *testing.T) {
-			d, _ := NewComparableTree(4)
-			ln, ok := d.root.(*comparableLeafNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, false)
-			}
-			d.Insert(testString("30"), "thirty")
-			d.Insert(testString("10"), "ten")
-			d.Insert(testString("30"), "THIRTY")
-			d.Insert(testString("20"), "twenty")
-			ensureComparableLeaves(t, ln, &comparableLeafNode{
-				runts:  cls("10", "20", "30"),
-				values: []interface{}{"ten", "twenty", "THIRTY"},
-			})
-		})
-		t.Run("when after final runt", func(t *testing.T) {
-			d, _ := NewComparableTree(4)
-			ln, ok := d.root.(*comparableLeafNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, false)
-			}
-			d.Insert(testString("30"), "thirty")
-			d.Insert(testString("10"), "ten")
-			d.Insert(testString("30"), "THIRTY")
-			d.Insert(testString("20"), "twenty")
-			d.Insert(testString("40"), "forty")
-			ensureComparableLeaves(t, ln, &comparableLeafNode{
-				runts:  cls("10", "20", "30", "40"),
-				values: []interface{}{"ten", "twenty", "THIRTY", "forty"},
-			})
-		})
-	})
-
-	t.Run("when insertion splits single leaf node", func(t *testing.T) {
-		gimme := func() *ComparableTree {
-			d, _ := NewComparableTree(4)
-			for k, v := range map[string]string{"10": "ten", "20": "twenty", "30": "thirty", "40": "forty"} {
-				d.Insert(testString(k), v)
-			}
-			// t.Logf("init root runts: %v\ninit root values: %v\n", d.root.(*leaf).runts, d.root.(*leaf).values)
-			return d
-		}
-		t.Run("when new key will be first node in left leaf", func(t *testing.T) {
-			d := gimme()
-			d.Insert(testString("0"), "zero")
-			root, ok := d.root.(*comparableInternalNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, true)
-			}
-			// root should have two runts and two leaf nodes for children
-			if got, want := len(root.runts), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			if got, want := len(root.children), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			// ensure children nodes are as expected for this case
-			if got, want := string(root.runts[0].(testString)), "0"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[0], &comparableLeafNode{
-				runts:  cls("0", "10", "20"),
-				values: []interface{}{"zero", "ten", "twenty"},
-				next:   root.children[1].(*comparableLeafNode),
-			})
-
-			if got, want := string(root.runts[1].(testString)), "30"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[1], &comparableLeafNode{
-				runts:  cls("30", "40"),
-				values: []interface{}{"thirty", "forty"},
-			})
-		})
-		t.Run("when new key is in middle", func(t *testing.T) {
-			d := gimme()
-			d.Insert(testString("25"), "twenty-five")
-			root, ok := d.root.(*comparableInternalNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, true)
-			}
-			// root should have two runts and two leaf nodes for children
-			if got, want := len(root.runts), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			if got, want := len(root.children), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			// ensure children nodes are as expected for this case
-			if got, want := string(root.runts[0].(testString)), "10"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[0], &comparableLeafNode{
-				runts:  cls("10", "20", "25"),
-				values: []interface{}{"ten", "twenty", "twenty-five"},
-				next:   root.children[1].(*comparableLeafNode),
-			})
-
-			if got, want := string(root.runts[1].(testString)), "30"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[1], &comparableLeafNode{
-				runts:  cls("30", "40"),
-				values: []interface{}{"thirty", "forty"},
-			})
-		})
-		t.Run("when new key will be final node in right leaf", func(t *testing.T) {
-			d := gimme()
-			d.Insert(testString("50"), "fifty")
-			root, ok := d.root.(*comparableInternalNode)
-			if !ok {
-				t.Fatalf("GOT: %v; WANT: %v", ok, true)
-			}
-			// root should have two runts and two leaf nodes for children
-			if got, want := len(root.runts), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			if got, want := len(root.children), 2; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			// ensure children nodes are as expected for this case
-			if got, want := string(root.runts[0].(testString)), "10"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[0], &comparableLeafNode{
-				runts:  cls("10", "20"),
-				values: []interface{}{"ten", "twenty"},
-				next:   root.children[1].(*comparableLeafNode),
-			})
-
-			if got, want := string(root.runts[1].(testString)), "30"; got != want {
-				t.Fatalf("GOT: %v; WANT: %v", got, want)
-			}
-			ensureComparableLeaves(t, root.children[1], &comparableLeafNode{
-				runts:  cls("30", "40", "50"),
-				values: []interface{}{"thirty", "forty", "fifty"},
-			})
-		})
-	})
-}
-
 func TestComparableTreeSearch(t *testing.T) {
 	t.Run("empty tree", func(t *testing.T) {
 		d, _ := NewComparableTree(16)
@@ -914,3 +319,103 @@ func TestComparableDelete(t *testing.T) {
 		d.Delete(testString(strconv.Itoa(3)))
 	})
 }
+
+func TestNewComparableTreeFromSorted(t *testing.T) {
+	pairs := func(items ...string) iter.Seq2[Comparable, interface{}] {
+		return func(yield func(Comparable, interface{}) bool) {
+			for i, item := range items {
+				if !yield(testString(item), i) {
+					return
+				}
+			}
+		}
+	}
+
+	t.Run("sorted", func(t *testing.T) {
+		d, err := NewComparableTreeFromSorted(4, pairs("a", "b", "c", "d", "e", "f", "g"))
+		ensureError(t, err)
+
+		var values []int
+		c := d.NewScanner(testString(""))
+		for c.Scan() {
+			_, v := c.Pair()
+			values = append(values, v.(int))
+		}
+		if got, want := fmt.Sprint(values), "[0 1 2 3 4 5 6]"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("not sorted", func(t *testing.T) {
+		_, err := NewComparableTreeFromSorted(4, pairs("a", "c", "b"))
+		ensureError(t, err, "not strictly increasing")
+	})
+}
+
+func TestComparerTree(t *testing.T) {
+	d, err := NewComparerTree[testComparer, int](4)
+	ensureError(t, err)
+
+	for _, v := range randomizedValues[:100] {
+		d.Insert(testComparer(strconv.Itoa(v)), v)
+	}
+	for _, v := range randomizedValues[:100] {
+		value, ok := d.Search(testComparer(strconv.Itoa(v)))
+		if got, want := ok, true; got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, v; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	var previous testComparer
+	c := d.NewScanner("")
+	for c.Scan() {
+		k, _ := c.Pair()
+		if k <= previous {
+			t.Errorf("GOT: %q; WANT: > %q", k, previous)
+		}
+		previous = k
+	}
+}
+
+func TestComparableKey(t *testing.T) {
+	d, err := NewComparerTree[ComparableKey, string](4)
+	ensureError(t, err)
+
+	for _, v := range []string{"d", "b", "e", "a", "c"} {
+		d.Insert(ComparableKey{testString(v)}, v)
+	}
+	d.Delete(ComparableKey{testString("c")})
+
+	var values []string
+	c := d.NewScanner(ComparableKey{testString("")})
+	for c.Scan() {
+		_, v := c.Pair()
+		values = append(values, v)
+	}
+	if got, want := strings.Join(values, ","), "a,b,d,e"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestEnsureSameKeyType(t *testing.T) {
+	t.Run("same type", func(t *testing.T) {
+		ensureSameKeyType(testString("a"), testString("b"))
+	})
+	t.Run("different types", func(t *testing.T) {
+		defer func() {
+			r := recover()
+			if r == nil {
+				t.Fatalf("GOT: %v; WANT: panic", r)
+			}
+			msg, _ := r.(string)
+			for _, stub := range []string{"gobptree.testString", "gobptree.otherString"} {
+				if !strings.Contains(msg, stub) {
+					t.Errorf("GOT: %q; WANT: %q", msg, stub)
+				}
+			}
+		}()
+		ensureSameKeyType(testString("a"), otherString("b"))
+	})
+}
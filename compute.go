@@ -0,0 +1,169 @@
+package gobptree
+
+// ComputeAction specifies what Compute does with the key after its callback
+// returns.
+type ComputeAction int
+
+const (
+	// ComputeStore stores the value returned by the callback as the new value
+	// for the key, creating the key when it is not in the tree.
+	ComputeStore ComputeAction = iota
+
+	// ComputeDelete removes the key from the tree when it is in the tree, and
+	// otherwise leaves the key absent.
+	ComputeDelete
+
+	// ComputeSkip leaves the tree unchanged, so the key keeps its value when it
+	// is in the tree, and remains absent when it is not.
+	ComputeSkip
+)
+
+// Compute searches for key and invokes callback with key's associated value,
+// or the zero value of V and false when key is not found, and then stores,
+// deletes, or skips the key as directed by the action callback returns. Unlike
+// Update, the callback may leave a missing key absent, or remove the key from
+// the tree.
+//
+//	// Release a reference, and drop the entry when none remain.
+//	t.Compute(key, func(count int, ok bool) (int, gobptree.ComputeAction) {
+//	    if !ok || count <= 1 {
+//	        return 0, gobptree.ComputeDelete
+//	    }
+//	    return count - 1, gobptree.ComputeStore
+//	})
+//
+// Whether the callback creates the key, removes it, or neither, is not known
+// until the leaf node where key belongs is reached, so rather than restoring
+// nodes on the way down like Insert and Delete, Compute holds the lock on every
+// node from the root to that leaf until it completes, and rebalances the nodes
+// along that path that become too small on the way back up. Nodes that become
+// full when the key is created are split while their parent is still locked.
+func (t *Tree[K, V]) Compute(key K, callback func(V, bool) (V, ComputeAction)) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	n := t.mutableRoot(t.lockRoot())
+	for {
+		root, ok := n.(*internalNode[K, V])
+		if !ok || len(root.children) > 1 {
+			break
+		}
+		// DeleteRange might leave the root with a single child, which
+		// has outlived its usefulness, and cannot restore that child.
+		child := root.mutableChild(0)
+		child.lock()
+		t.setRoot(child)
+		root.unlock()
+		n = child
+	}
+	defer n.unlock()
+
+	delta, tooSmall := n.computeKey(t.order, key, t.compare, callback)
+	if delta != 0 {
+		t.length.Add(int64(delta))
+	}
+
+	if left, right := n.maybeSplit(t.order); right != nil {
+		// Regardless of whether the root is an internal or a leaf node, the
+		// root shall become an internal node.
+		t.setRoot(t.newRoot(left.smallest(), left, right))
+		return
+	}
+	if !tooSmall || n.count() > 1 {
+		// Root is only too small when fewer than 2 children
+		return
+	}
+	// Root might be an internal or a leaf node. If leaf node, the root is
+	// already as small as can be.
+	if root, ok := n.(*internalNode[K, V]); ok {
+		// Root has outlived its usefulness when it has only a single child.
+		t.setRoot(root.children[0])
+	}
+}
+
+// computeKey invokes callback for key in the subtree rooted at this node and
+// applies its action. It returns the change in the number of keys in the
+// subtree, and whether this node has become too small as a result. A node is
+// too small when it holds fewer than order keys or children. When the child
+// where key belongs becomes full, it is split before this returns.
+func (i *internalNode[K, V]) computeKey(order int, key K, compare func(a, b K) int, callback func(V, bool) (V, ComputeAction)) (int, bool) {
+	index := searchLessThanOrEqualTo(key, i.runts, compare)
+	child := i.mutableChild(index)
+	child.lock()
+
+	var shrunk bool
+	if len(i.children) > 1 && child.isInternal() && child.count() == 1 {
+		// DeleteRange might leave the child with a single child, which it
+		// cannot restore when that becomes too small, so like
+		// lockChildForDelete, restore the child before descending. rebalanceChild locks the left
+		// sibling before the child.
+		child.unlock()
+		shrunk = i.rebalanceChild(index, order)
+		index = searchLessThanOrEqualTo(key, i.runts, compare)
+		child = i.children[index]
+		child.lock()
+	}
+
+	if index == 0 {
+		if smallest := child.smallest(); compare(key, smallest) < 0 {
+			// preemptively update smallest value, in case key is created
+			i.runts[0] = key
+		}
+	}
+
+	delta, tooSmall := child.computeKey(order, key, compare, callback)
+	if i.counts != nil {
+		i.counts[index] += delta
+	}
+	// The callback might have replaced the value without changing the number
+	// of keys.
+	i.summarize(index)
+
+	_, right := child.maybeSplit(order)
+	child.unlock()
+	if right != nil {
+		i.insertSibling(index, right)
+		return delta, shrunk && len(i.runts) < order
+	}
+	if !tooSmall {
+		return delta, shrunk
+	}
+	return delta, i.rebalanceChild(index, order) || shrunk && len(i.runts) < order
+}
+
+// computeKey invokes callback for key in this leaf and applies its action. It
+// returns the change in the number of keys in this leaf, and whether this leaf
+// has become too small as a result.
+func (l *leafNode[K, V]) computeKey(order int, key K, compare func(a, b K) int, callback func(V, bool) (V, ComputeAction)) (int, bool) {
+	var value V
+
+	index, found := l.search(key, compare)
+	if found {
+		value = l.values[index]
+	}
+
+	value, action := callback(value, found)
+
+	switch action {
+	case ComputeStore:
+		if found {
+			l.values[index] = value
+			return 0, false
+		}
+		l.insertAt(index, key, value)
+		return 1, false
+	case ComputeDelete:
+		if !found {
+			return 0, false
+		}
+		copy(l.runts[index:], l.runts[index+1:])
+		copy(l.values[index:], l.values[index+1:])
+		var zero V
+		l.values[len(l.values)-1] = zero
+		l.runts = l.runts[:len(l.runts)-1]
+		l.values = l.values[:len(l.values)-1]
+		return -1, len(l.runts) < order
+	default:
+		return 0, false
+	}
+}
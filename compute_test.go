@@ -0,0 +1,246 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestTreeCompute(t *testing.T) {
+	forEachKeyType(t, testTreeCompute[int32], testTreeCompute[int64], testTreeCompute[uint32], testTreeCompute[uint64], testTreeCompute[string])
+}
+
+func testTreeCompute[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureValue := func(t *testing.T, d *Tree[K, interface{}], key K, value interface{}, ok bool) {
+		t.Helper()
+		v, found := d.Search(key)
+		if found != ok || v != value {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", v, found, value, ok)
+		}
+	}
+
+	t.Run("store", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		d.Compute(k(1), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			if ok || value != nil {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, nil, false)
+			}
+			return "first", ComputeStore
+		})
+		ensureValue(t, d, k(1), "first", true)
+
+		d.Compute(k(1), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			if !ok || value != "first" {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, "first", true)
+			}
+			return "second", ComputeStore
+		})
+		ensureValue(t, d, k(1), "second", true)
+		if got, want := d.Len(), 1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("splits full nodes", func(t *testing.T) {
+		const order = 4
+
+		d, _ := NewTree[K, interface{}](order)
+		for _, v := range rand.New(rand.NewSource(1)).Perm(1000) {
+			d.Compute(k(v), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+				return v, ComputeStore
+			})
+		}
+
+		var walk func(n node[K, interface{}])
+		walk = func(n node[K, interface{}]) {
+			if got := n.count(); got > order {
+				t.Errorf("GOT: %v; WANT: <= %v", got, order)
+			}
+			if i, ok := n.(*internalNode[K, interface{}]); ok {
+				for _, child := range i.children {
+					walk(child)
+				}
+			}
+		}
+		walk(d.root)
+		ensureStructure(t, d)
+		ensureLeafLinks(t, d)
+		if got, want := d.Len(), 1000; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("skip", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		d.Insert(k(1), "first")
+
+		d.Compute(k(1), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			return "ignored", ComputeSkip
+		})
+		ensureValue(t, d, k(1), "first", true)
+
+		// A missing key remains absent.
+		d.Compute(k(2), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			return "ignored", ComputeSkip
+		})
+		ensureValue(t, d, k(2), nil, false)
+		if got, want := d.Len(), 1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("delete", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 50; i++ {
+			d.Insert(k(i), i)
+		}
+
+		d.Compute(k(7), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			if !ok || value != 7 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 7, true)
+			}
+			return nil, ComputeDelete
+		})
+		ensureValue(t, d, k(7), nil, false)
+
+		// Deleting a missing key leaves it absent.
+		d.Compute(k(99), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			return nil, ComputeDelete
+		})
+		ensureValue(t, d, k(99), nil, false)
+		if got, want := d.Len(), 49; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+
+		// Deleting every key rebalances the tree like Delete does.
+		for i := 0; i < 50; i++ {
+			d.Compute(k(i), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+				return nil, ComputeDelete
+			})
+			ensureStructure(t, d)
+		}
+		ensureNode(t, d.root, node[K, interface{}](k.leafFrom(nil)))
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 256
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			d, _ := NewTree[K, interface{}](order)
+			m := make(map[int]int)
+
+			for step := 0; step < 4000; step++ {
+				v := r.Intn(keyCount)
+				// Acquire a reference two times out of three, and release a
+				// reference otherwise, dropping the key when none remain.
+				acquire := r.Intn(3) > 0
+				d.Compute(k(v), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+					var count int
+					if ok {
+						count = value.(int)
+					}
+					if acquire {
+						return count + 1, ComputeStore
+					}
+					if count <= 1 {
+						return nil, ComputeDelete
+					}
+					return count - 1, ComputeStore
+				})
+				if acquire {
+					m[v]++
+				} else if m[v] <= 1 {
+					delete(m, v)
+				} else {
+					m[v]--
+				}
+
+				if step%50 == 0 {
+					var expected []int
+					for i := 0; i < keyCount; i++ {
+						if count, ok := m[i]; ok {
+							expected = append(expected, i)
+							ensureValue(t, d, k(i), count, true)
+						}
+					}
+					ensureScan(t, d, k(0), k.slice(expected...))
+					ensureLeafLinks(t, d)
+					ensureStructure(t, d)
+				}
+				if got, want := d.Len(), len(m); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("restores nodes with a single child", func(t *testing.T) {
+		leafD := k.leafFrom(nil, 40)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+		leafB.prev.Store(leafA)
+		leafC.prev.Store(leafB)
+		leafD.prev.Store(leafC)
+		// A range deletion might leave an internal node with a single child,
+		// which cannot restore that child when it becomes too small.
+		root := internalFrom[K](internalFromLeaves(leafA, leafB, leafC), internalFromLeaves(leafD))
+		d := &Tree[K, interface{}]{root: root, compare: cmp.Compare[K], order: 4}
+		d.length.Store(13)
+
+		d.Compute(k(40), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			return nil, ComputeDelete
+		})
+
+		ensureScan(t, d, k(0), k.slice(10, 12, 14, 16, 20, 22, 24, 26, 30, 32, 34, 36))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+		if got, want := d.Len(), 12; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const keyCount = 500
+
+		d, _ := NewTree[K, interface{}](4)
+
+		release := func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			if !ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, true)
+				return nil, ComputeSkip
+			}
+			if count := value.(int); count > 1 {
+				return count - 1, ComputeStore
+			}
+			return nil, ComputeDelete
+		}
+		acquire := func(value interface{}, ok bool) (interface{}, ComputeAction) {
+			if !ok {
+				return 1, ComputeStore
+			}
+			return value.(int) + 1, ComputeStore
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for i := 0; i < keyCount; i++ {
+					d.Compute(k(i), acquire)
+				}
+				for i := 0; i < keyCount; i++ {
+					d.Compute(k(i), release)
+				}
+			}()
+		}
+		wg.Wait()
+
+		// Every reference was released, so every key was dropped.
+		ensureScan(t, d, k(0), nil)
+		if got, want := d.Len(), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
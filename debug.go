@@ -0,0 +1,8 @@
+//go:build gobptree_debug
+
+package gobptree
+
+// debug is true when the library is built with the gobptree_debug build tag,
+// which causes trees whose key type is an interface type to panic when they
+// compare keys of different dynamic types.
+const debug = true
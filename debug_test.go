@@ -0,0 +1,49 @@
+//go:build gobptree_debug
+
+package gobptree
+
+import (
+	"strings"
+	"testing"
+)
+
+func ensurePanic(t *testing.T, contains string, callback func()) {
+	t.Helper()
+	defer func() {
+		t.Helper()
+		r := recover()
+		if r == nil {
+			t.Fatalf("GOT: %v; WANT: panic", r)
+		}
+		if msg, _ := r.(string); !strings.Contains(msg, contains) {
+			t.Errorf("GOT: %q; WANT: %q", msg, contains)
+		}
+	}()
+	callback()
+}
+
+func TestDebugPanicsWhenKeyTypesDiffer(t *testing.T) {
+	t.Run("ComparableTree", func(t *testing.T) {
+		d, _ := NewComparableTree(4)
+		d.Insert(testString("a"), 1)
+		ensurePanic(t, "cannot compare key", func() {
+			d.Insert(otherString("b"), 2)
+		})
+	})
+	t.Run("ComparableKey", func(t *testing.T) {
+		d, _ := NewComparerTree[ComparableKey, int](4)
+		d.Insert(ComparableKey{testString("a")}, 1)
+		ensurePanic(t, "cannot compare key", func() {
+			d.Search(ComparableKey{otherString("b")})
+		})
+	})
+	t.Run("interface key type", func(t *testing.T) {
+		d, _ := NewTreeFunc[interface{}, int](4, func(a, b interface{}) int {
+			return strings.Compare(a.(string), b.(string))
+		})
+		d.Insert("a", 1)
+		ensurePanic(t, "cannot compare key", func() {
+			d.Insert(42, 2)
+		})
+	})
+}
@@ -0,0 +1,294 @@
+package gobptree
+
+// DeleteRange removes every key-value pair from the tree whose key is greater
+// than or equal to lo and less than or equal to hi, and returns the number of
+// pairs removed.
+//
+// Rather than deleting one key at a time, this trims the leaf nodes at either
+// end of the range, unlinks every leaf and internal node that lies entirely
+// within the range in a single pass, and then rebalances the nodes along the
+// two edges of the range once. Nodes within the range are visited to count
+// their keys, and when the leaves are linked, to empty them, except that a
+// tree that maintains counts and whose leaves are not linked, because it has
+// been cloned for instance, discards them without visiting them. Unlike
+// Delete, it holds the lock on the root node until it completes, along with
+// the lock on every node it modifies.
+func (t *Tree[K, V]) DeleteRange(lo, hi K) int {
+	if t.compare(lo, hi) > 0 {
+		return 0
+	}
+
+	t.beginWrite()
+	defer t.endWrite()
+
+	d := &rangeDeletion[K, V]{
+		t:      t,
+		lo:     lo,
+		hi:     hi,
+		linked: t.cow.Load() == nil,
+		isHeld: make(map[node[K, V]]bool),
+	}
+	root := t.mutableRoot(t.lockRoot())
+	d.held = append(d.held, root)
+	d.isHeld[root] = true
+	defer d.unlockAll()
+
+	d.deleteFrom(root)
+	if d.linked {
+		d.relinkLeaves()
+	}
+	d.rebalance(root, lo)
+	d.rebalance(root, hi)
+
+	// Root has outlived its usefulness when it has only a single child, and
+	// might have no children when every key in the tree was removed.
+	for n := root; n.isInternal(); {
+		i := n.(*internalNode[K, V])
+		if len(i.children) == 0 {
+			t.setRoot(&leafNode[K, V]{
+				runts:  make([]K, 0, t.order),
+				values: make([]V, 0, t.order),
+				cow:    t.cow.Load(),
+			})
+			break
+		}
+		if len(i.children) > 1 {
+			break
+		}
+		n = i.children[0]
+		t.setRoot(n)
+	}
+
+	t.length.Add(-int64(d.removed))
+	return d.removed
+}
+
+// rangeDeletion holds the state of a DeleteRange operation. Every node it
+// locks remains locked until the operation completes. Internal nodes are
+// always locked after their parent, and while holding the lock on a leaf, the
+// operation only locks the leaves after it, like every other operation and
+// cursor that holds the lock on a leaf while waiting for another, so it cannot
+// deadlock with them.
+type rangeDeletion[K any, V any] struct {
+	t       *Tree[K, V]
+	lo, hi  K
+	linked  bool // when true the leaves are linked, and are relinked after the keys are removed
+	held    []node[K, V]
+	isHeld  map[node[K, V]]bool
+	pred    *leafNode[K, V]   // the leaf before the first leaf visited
+	leaves  []*leafNode[K, V] // the leaves visited, in ascending order
+	removed int
+}
+
+// lock acquires the lock on n unless the operation already holds it.
+func (d *rangeDeletion[K, V]) lock(n node[K, V]) {
+	if !d.isHeld[n] {
+		n.lock()
+		d.held = append(d.held, n)
+		d.isHeld[n] = true
+	}
+}
+
+func (d *rangeDeletion[K, V]) unlockAll() {
+	for _, n := range d.held {
+		n.unlock()
+	}
+}
+
+// deleteFrom removes the keys within the range from the subtree rooted at the
+// locked node n, and removes every child node left empty.
+func (d *rangeDeletion[K, V]) deleteFrom(n node[K, V]) {
+	compare := d.t.compare
+
+	if l, ok := n.(*leafNode[K, V]); ok {
+		first := searchGreaterThanOrEqualTo(d.lo, l.runts, compare)
+		if first < len(l.runts) && compare(l.runts[first], d.lo) < 0 {
+			first++
+		}
+		last := searchGreaterThanOrEqualTo(d.hi, l.runts, compare)
+		if last < len(l.runts) && compare(l.runts[last], d.hi) <= 0 {
+			last++
+		}
+		if first < last {
+			copy(l.runts[first:], l.runts[last:])
+			copy(l.values[first:], l.values[last:])
+			count := len(l.runts) - (last - first)
+			clear(l.values[count:])
+			l.runts = l.runts[:count]
+			l.values = l.values[:count]
+			d.removed += last - first
+		}
+		d.leaves = append(d.leaves, l)
+		return
+	}
+
+	i := n.(*internalNode[K, V])
+	first := searchLessThanOrEqualTo(d.lo, i.runts, compare)
+	last := searchLessThanOrEqualTo(d.hi, i.runts, compare)
+
+	if first > 0 && d.linked {
+		// The leaf before the range might be the final leaf of the previous
+		// child, and its next pointer will need to be updated. Its lock must
+		// be acquired before the lock of any leaf after it.
+		d.lockRightmostLeaf(i.children[first-1])
+	}
+
+	for j := first; j <= last; j++ {
+		removed := d.removed
+		if first < j && j < last {
+			// Every key under the children between the first and final
+			// children is within the range.
+			if i.counts != nil && !d.linked {
+				// Every operation that modifies a tree that maintains counts
+				// holds the lock on this node while it modifies a node below
+				// it, so the count of the child is exact, and with no leaves
+				// to unlink, the child need not be visited.
+				d.removed += i.counts[j]
+			} else {
+				child := i.children[j]
+				d.lock(child)
+				d.discard(child)
+			}
+		} else {
+			child := i.mutableChild(j)
+			d.lock(child)
+			d.deleteFrom(child)
+		}
+		if i.counts != nil {
+			i.counts[j] -= d.removed - removed
+		}
+		i.summarize(j)
+	}
+
+	// Remove the children between the first and final children, and those
+	// left empty.
+	k := first
+	for j := first; j <= last; j++ {
+		if (j == first || j == last) && i.children[j].count() > 0 {
+			i.runts[k] = i.runts[j]
+			i.children[k] = i.children[j]
+			if i.counts != nil {
+				i.counts[k] = i.counts[j]
+			}
+			if i.summaries != nil {
+				i.summaries[k] = i.summaries[j]
+			}
+			k++
+		}
+	}
+	if k <= last {
+		count := len(i.runts) - (last + 1 - k)
+		copy(i.runts[k:], i.runts[last+1:])
+		copy(i.children[k:], i.children[last+1:])
+		clear(i.children[count:])
+		i.runts = i.runts[:count]
+		i.children = i.children[:count]
+		if i.counts != nil {
+			copy(i.counts[k:], i.counts[last+1:])
+			i.counts = i.counts[:count]
+		}
+		if i.summaries != nil {
+			copy(i.summaries[k:], i.summaries[last+1:])
+			clear(i.summaries[count:])
+			i.summaries = i.summaries[:count]
+		}
+	}
+}
+
+// discard counts every key under the locked node n. When the leaves are
+// linked it also empties every node under n, so the leaves may be unlinked.
+// Otherwise the nodes might be shared with a clone of the tree, and are left
+// unmodified.
+func (d *rangeDeletion[K, V]) discard(n node[K, V]) {
+	if l, ok := n.(*leafNode[K, V]); ok {
+		d.removed += len(l.runts)
+		if d.linked {
+			l.runts = nil
+			l.values = nil
+			d.leaves = append(d.leaves, l)
+		}
+		return
+	}
+	i := n.(*internalNode[K, V])
+	for _, child := range i.children {
+		d.lock(child)
+		d.discard(child)
+	}
+	if !d.linked {
+		return
+	}
+	i.runts = nil
+	i.children = nil
+	i.counts = nil
+	i.summaries = nil
+}
+
+// lockRightmostLeaf locks every node from n down to the final leaf under n,
+// which becomes the leaf before the first leaf visited.
+func (d *rangeDeletion[K, V]) lockRightmostLeaf(n node[K, V]) {
+	d.lock(n)
+	for n.isInternal() {
+		i := n.(*internalNode[K, V])
+		n = i.children[len(i.children)-1]
+		d.lock(n)
+	}
+	d.pred = n.(*leafNode[K, V])
+}
+
+// relinkLeaves links the leaves that still hold keys with each other, and with
+// the leaves before and after those visited.
+func (d *rangeDeletion[K, V]) relinkLeaves() {
+	after := d.leaves[len(d.leaves)-1].next
+	tail := d.pred
+	for _, l := range d.leaves {
+		if len(l.runts) == 0 {
+			l.next = nil
+			l.prev.Store(nil)
+			continue
+		}
+		if tail != nil {
+			tail.next = l
+		}
+		l.prev.Store(tail)
+		tail = l
+	}
+	if tail != nil {
+		tail.next = after
+	}
+	if after != nil {
+		after.prev.Store(tail)
+	}
+}
+
+// rebalance descends from n along the path to key, merging each node with its
+// right sibling when either is too small and both fit in a single node. Only
+// right siblings are considered, so that leaves are locked in ascending order.
+func (d *rangeDeletion[K, V]) rebalance(n node[K, V], key K) {
+	minSize := d.t.order
+
+	for n.isInternal() {
+		i := n.(*internalNode[K, V])
+		if len(i.children) == 0 {
+			return
+		}
+		index := searchLessThanOrEqualTo(key, i.runts, d.t.compare)
+		child := i.mutableChild(index)
+		d.lock(child)
+
+		if index < len(i.children)-1 {
+			sibling := i.mutableChild(index + 1)
+			d.lock(sibling)
+			if childCount, siblingCount := child.count(), sibling.count(); (childCount < minSize || siblingCount < minSize) && childCount+siblingCount < 2*minSize {
+				child.absorbRight(sibling)
+				i.mergeAggregates(index)
+				copy(i.runts[index+1:], i.runts[index+2:])
+				i.runts = i.runts[:len(i.runts)-1]
+				copy(i.children[index+1:], i.children[index+2:])
+				i.children[len(i.children)-1] = nil
+				i.children = i.children[:len(i.children)-1]
+			}
+		}
+
+		n = child
+	}
+}
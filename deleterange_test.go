@@ -0,0 +1,281 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// ensureStructure ensures every node in the tree other than the root holds at
+// least one key or child, that every runt is no greater than the smallest key
+// under its child, and that every leaf is at the same depth.
+func ensureStructure[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}]) {
+	t.Helper()
+
+	leafDepth := -1
+	var walk func(n node[K, interface{}], depth int, isRoot bool)
+	walk = func(n node[K, interface{}], depth int, isRoot bool) {
+		t.Helper()
+		if !isRoot && n.count() == 0 {
+			t.Errorf("depth %d: GOT: empty node; WANT: non-empty node", depth)
+			return
+		}
+		i, ok := n.(*internalNode[K, interface{}])
+		if !ok {
+			if leafDepth < 0 {
+				leafDepth = depth
+			} else if leafDepth != depth {
+				t.Errorf("GOT: leaf at depth %d; WANT: %d", depth, leafDepth)
+			}
+			return
+		}
+		for j, child := range i.children {
+			walk(child, depth+1, false)
+			if child.count() > 0 && i.runts[j] > child.smallest() {
+				t.Errorf("depth %d: runts[%d] GOT: %v; WANT: <= %v", depth, j, i.runts[j], child.smallest())
+			}
+		}
+	}
+	walk(d.root, 0, true)
+}
+
+func TestTreeDeleteRange(t *testing.T) {
+	forEachKeyType(t, testTreeDeleteRange[int32], testTreeDeleteRange[int64], testTreeDeleteRange[uint32], testTreeDeleteRange[uint64], testTreeDeleteRange[string])
+}
+
+func testTreeDeleteRange[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureDeleteRange := func(t *testing.T, d *Tree[K, interface{}], lo, hi int, remaining []int) {
+		t.Helper()
+
+		before := d.Len()
+		if got, want := d.DeleteRange(k(lo), k(hi)), before-len(remaining); got != want {
+			t.Errorf("[%v, %v] GOT: %v; WANT: %v", lo, hi, got, want)
+		}
+		if got, want := d.Len(), len(remaining); got != want {
+			t.Errorf("[%v, %v] GOT: %v; WANT: %v", lo, hi, got, want)
+		}
+		ensureScan(t, d, k(0), k.slice(remaining...))
+		reversed := make([]int, len(remaining))
+		for i, v := range remaining {
+			reversed[len(remaining)-1-i] = v
+		}
+		ensureReverseScan(t, d, k(1<<20), k.slice(reversed...))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+	}
+
+	newTree := func(order, count int) (*Tree[K, interface{}], []int) {
+		d, _ := NewTree[K, interface{}](order)
+		keys := make([]int, count)
+		for i := 0; i < count; i++ {
+			keys[i] = 2 * (i + 1)
+			d.Insert(k(keys[i]), k(keys[i]))
+		}
+		return d, keys
+	}
+
+	without := func(keys []int, lo, hi int) []int {
+		var remaining []int
+		for _, key := range keys {
+			if key < lo || key > hi {
+				remaining = append(remaining, key)
+			}
+		}
+		return remaining
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureDeleteRange(t, d, 0, 99, nil)
+	})
+	t.Run("lo greater than hi", func(t *testing.T) {
+		d, keys := newTree(4, 20)
+		ensureDeleteRange(t, d, 20, 10, keys)
+	})
+	t.Run("every range", func(t *testing.T) {
+		for _, order := range []int{4, 8} {
+			for lo := 0; lo <= 62; lo += 3 {
+				for hi := lo; hi <= 62; hi += 5 {
+					d, keys := newTree(order, 30)
+					ensureDeleteRange(t, d, lo, hi, without(keys, lo, hi))
+					if t.Failed() {
+						t.Fatalf("ORDER: %d; RANGE: [%v, %v]", order, lo, hi)
+					}
+				}
+			}
+		}
+	})
+	t.Run("merges edges of range", func(t *testing.T) {
+		leafD := k.leafFrom(nil, 40, 42, 44, 46)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+		d := &Tree[K, interface{}]{root: internalFromLeaves(leafA, leafB, leafC, leafD), compare: cmp.Compare[K], order: 4}
+		d.length.Store(16)
+
+		ensureDeleteRange(t, d, 14, 42, []int{10, 12, 44, 46})
+
+		// The first and final leaves were trimmed and merged, leaving the root
+		// with a single child, which replaced it.
+		ensureNode(t, d.root, node[K, interface{}](k.leafFrom(nil, 10, 12, 44, 46)))
+	})
+	t.Run("counted tree that has been cloned", func(t *testing.T) {
+		d, _ := NewOrderStatisticTree[K, interface{}](4)
+		for i := 0; i < 500; i++ {
+			d.Insert(k(i), i)
+		}
+		c := d.Clone()
+
+		// The nodes within the range are discarded without being visited, so
+		// DeleteRange does not wait for the lock on a leaf within it.
+		l := d.lockLeafForSearch(k(250))
+		if got, want := d.DeleteRange(k(100), k(399)), 300; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		l.unlock()
+
+		var expected []int
+		for i := 0; i < 500; i++ {
+			if i < 100 || i >= 400 {
+				expected = append(expected, i)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		if got, want := d.Len(), len(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureStructure(t, d)
+		ensureCounts(t, d)
+		if got, want := c.Len(), 500; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureCounts(t, c)
+	})
+	t.Run("every key", func(t *testing.T) {
+		d, _ := newTree(4, 500)
+		ensureDeleteRange(t, d, 0, 1<<20, nil)
+
+		// The tree remains usable.
+		d.Insert(k(5), k(5))
+		ensureScan(t, d, k(0), k.slice(5))
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 512
+
+		r := rand.New(rand.NewSource(1))
+		d, _ := NewTree[K, interface{}](4)
+		m := make(map[int]bool)
+
+		for step := 0; step < 500; step++ {
+			for i := 0; i < 20; i++ {
+				v := r.Intn(keyCount)
+				d.Insert(k(v), k(v))
+				m[v] = true
+			}
+
+			lo := r.Intn(keyCount)
+			hi := lo + r.Intn(keyCount/4)
+			var remaining []int
+			for i := 0; i < keyCount; i++ {
+				if m[i] && (i < lo || i > hi) {
+					remaining = append(remaining, i)
+				} else {
+					delete(m, i)
+				}
+			}
+			ensureDeleteRange(t, d, lo, hi, remaining)
+			if t.Failed() {
+				t.Fatalf("STEP: %d; RANGE: [%v, %v]", step, lo, hi)
+			}
+		}
+	})
+	t.Run("followed by deletes", func(t *testing.T) {
+		const keyCount = 200
+
+		// A range deletion might leave an internal node with a single child,
+		// which cannot be restored by its own siblings when a later deletion
+		// leaves the child too small.
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			d, _ := NewTree[K, interface{}](order)
+			m := make(map[int]bool)
+
+			for step := 0; step < 3000; step++ {
+				v := r.Intn(keyCount)
+				switch r.Intn(4) {
+				case 0:
+					hi := v + r.Intn(30)
+					d.DeleteRange(k(v), k(hi))
+					for i := v; i <= hi; i++ {
+						delete(m, i)
+					}
+				case 1:
+					d.Delete(k(v))
+					delete(m, v)
+				default:
+					d.Insert(k(v), k(v))
+					m[v] = true
+				}
+			}
+
+			var remaining []int
+			for i := 0; i < keyCount; i++ {
+				if m[i] {
+					remaining = append(remaining, i)
+				}
+			}
+			ensureScan(t, d, k(0), k.slice(remaining...))
+			ensureStructure(t, d)
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const keyCount = 2000
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < keyCount; i++ {
+			d.Insert(k(i), k(i))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(3)
+		go func() {
+			defer wg.Done()
+			for lo := 100; lo < keyCount; lo += 200 {
+				d.DeleteRange(k(lo), k(lo+99))
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// Replace keys outside of the deleted ranges.
+			for i := 0; i < keyCount; i += 200 {
+				for j := i; j < i+100; j++ {
+					d.Insert(k(j), k(j))
+				}
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 4; i++ {
+				for c := d.NewScanner(k(0)); c.Scan(); {
+				}
+				for c := d.NewReverseScanner(k(keyCount)); c.Scan(); {
+				}
+			}
+		}()
+		wg.Wait()
+
+		var expected []int
+		for i := 0; i < keyCount; i += 200 {
+			for j := i; j < i+100; j++ {
+				expected = append(expected, j)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+		if got, want := d.Len(), len(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
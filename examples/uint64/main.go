@@ -48,6 +48,25 @@ func main() {
 		sortedValues = append(sortedValues, k)
 	}
 
+	fmt.Printf("%s: Creating a second B+Tree by bulk loading the sorted list.\n", formatTime())
+	// Because the keys are already sorted, the tree may be built from the
+	// bottom up, without splitting any nodes.
+	bulk, err := gobptree.NewUint64TreeFromSorted(int(*order), func(yield func(uint64, interface{}) bool) {
+		for _, k := range sortedValues {
+			if !yield(k, struct{}{}) {
+				return
+			}
+		}
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "ERROR: %s\n", err)
+		os.Exit(1)
+	}
+	if got, want := bulk.Len(), t.Len(); got != want {
+		fmt.Fprintf(os.Stderr, "GOT: %v; WANT: %v", got, want)
+		os.Exit(1)
+	}
+
 	fmt.Printf("%s: Searching tree for each value from the sorted list.\n", formatTime())
 	// Ensure enumerated order of the keys are in fact sorted, in other words, a
 	// slice of uint64 values from [0 to N).
@@ -1,5 +1,5 @@
 module github.com/karrick/gobptree
 
-go 1.18
+go 1.23
 
 require github.com/karrick/golf v1.4.0
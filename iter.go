@@ -0,0 +1,60 @@
+package gobptree
+
+import "iter"
+
+// All returns an iterator over every key-value pair in the tree in ascending
+// order.
+//
+//	for k, v := range t.All() {
+//	    fmt.Println(k, v)
+//	}
+//
+// Like the cursors it wraps, the iterator holds the lock on the leaf node of
+// the key-value pair it yields, so the body of the loop must not modify the
+// tree. The lock is released when the loop completes, when its body breaks
+// out of the loop, and when its body panics.
+func (t *Tree[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		yieldAll(newCursor(t, func(t *Tree[K, V]) (*leafNode[K, V], int) {
+			return t.lockLeftmostLeaf(), 0
+		}), yield)
+	}
+}
+
+// Ascend returns an iterator over the key-value pairs in the tree in
+// ascending order, starting at key, or if key is not found the next key. See
+// All for the locking performed by the iterator.
+func (t *Tree[K, V]) Ascend(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		yieldAll(t.NewScanner(key), yield)
+	}
+}
+
+// Descend returns an iterator over the key-value pairs in the tree in
+// descending order, starting at key, or if key is not found the previous key.
+// See All for the locking performed by the iterator.
+func (t *Tree[K, V]) Descend(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		yieldAll(t.NewReverseScanner(key), yield)
+	}
+}
+
+// Range returns an iterator over the key-value pairs in the tree in ascending
+// order, starting at lo and ending at hi, each of which is included unless
+// excluded by opts. See All for the locking performed by the iterator.
+func (t *Tree[K, V]) Range(lo, hi K, opts RangeOptions) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		yieldAll(t.NewRangeScanner(lo, hi, opts), yield)
+	}
+}
+
+// yieldAll passes every key-value pair from the cursor to yield until yield
+// returns false, and closes the cursor before it returns or panics.
+func yieldAll[K any, V any](c *Cursor[K, V], yield func(K, V) bool) {
+	defer c.Close()
+	for c.Scan() {
+		if !yield(c.Pair()) {
+			return
+		}
+	}
+}
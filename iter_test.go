@@ -0,0 +1,94 @@
+package gobptree
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"testing"
+)
+
+func TestTreeIterators(t *testing.T) {
+	forEachKeyType(t, testTreeIterators[int32], testTreeIterators[int64], testTreeIterators[uint32], testTreeIterators[uint64], testTreeIterators[string])
+}
+
+func testTreeIterators[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	collect := func(seq iter.Seq2[K, interface{}]) []K {
+		var keys []K
+		for key, value := range seq {
+			if got, want := value, interface{}(key); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	ensureKeys := func(t *testing.T, got []K, want []K) {
+		t.Helper()
+		if got, want := fmt.Sprint(got), fmt.Sprint(want); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	// ensureUnlocked ensures no leaf node remains locked, by inserting and
+	// deleting keys throughout the tree, which would otherwise deadlock.
+	ensureUnlocked := func(t *testing.T, d *Tree[K, interface{}]) {
+		t.Helper()
+		for i := 1; i < 30; i += 2 {
+			d.Insert(k(i), k(i))
+			d.Delete(k(i))
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureKeys(t, collect(d.All()), nil)
+		ensureKeys(t, collect(d.Ascend(k(0))), nil)
+		ensureKeys(t, collect(d.Descend(k(99))), nil)
+		ensureKeys(t, collect(d.Range(k(0), k(99), RangeOptions{})), nil)
+	})
+
+	d, _ := NewTree[K, interface{}](4)
+	for i := 0; i < 15; i++ {
+		d.Insert(k(2*i), k(2*i))
+	}
+
+	t.Run("all", func(t *testing.T) {
+		ensureKeys(t, collect(d.All()), k.slice(0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28))
+	})
+	t.Run("ascend", func(t *testing.T) {
+		ensureKeys(t, collect(d.Ascend(k(19))), k.slice(20, 22, 24, 26, 28))
+	})
+	t.Run("descend", func(t *testing.T) {
+		ensureKeys(t, collect(d.Descend(k(9))), k.slice(8, 6, 4, 2, 0))
+	})
+	t.Run("range", func(t *testing.T) {
+		ensureKeys(t, collect(d.Range(k(4), k(12), RangeOptions{ExcludeHi: true})), k.slice(4, 6, 8, 10))
+	})
+	t.Run("break releases leaf", func(t *testing.T) {
+		for _, seq := range []iter.Seq2[K, interface{}]{d.All(), d.Ascend(k(0)), d.Descend(k(99)), d.Range(k(0), k(99), RangeOptions{})} {
+			var count int
+			for range seq {
+				if count++; count == 3 {
+					break
+				}
+			}
+			ensureUnlocked(t, d)
+		}
+	})
+	t.Run("panic releases leaf", func(t *testing.T) {
+		for _, seq := range []iter.Seq2[K, interface{}]{d.All(), d.Ascend(k(0)), d.Descend(k(99)), d.Range(k(0), k(99), RangeOptions{})} {
+			func() {
+				defer func() {
+					if r := recover(); r == nil {
+						t.Errorf("GOT: %v; WANT: panic", r)
+					}
+				}()
+				for range seq {
+					panic("loop body")
+				}
+			}()
+			ensureUnlocked(t, d)
+		}
+	})
+}
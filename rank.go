@@ -0,0 +1,166 @@
+package gobptree
+
+import "cmp"
+
+// NewOrderStatisticTree returns a newly initialized Tree of the specified
+// order, whose keys are in ascending order, and which maintains the number of
+// keys under each child of its internal nodes. See NewOrderStatisticTreeFunc.
+func NewOrderStatisticTree[K cmp.Ordered, V any](order int) (*Tree[K, V], error) {
+	return NewOrderStatisticTreeFunc[K, V](order, cmp.Compare[K])
+}
+
+// NewOrderStatisticTreeFunc returns a newly initialized Tree of the specified
+// order, whose keys are ordered by compare, and which maintains the number of
+// keys under each child of its internal nodes, so that Rank, Select, and
+// CountRange visit a single path from the root to a leaf, rather than every
+// leaf before the answer.
+//
+// Maintaining the counts costs some of the parallelism of insertions and
+// deletions. Every count along the path from the root to a leaf changes when a
+// key is added to or removed from the leaf, but whether the key is new, or was
+// in the tree, is not known until the leaf is reached, so insertions into and
+// deletions from the tree hold the lock on every node along the path until
+// they complete.
+//
+//	// Scores in descending order, so the highest score has rank 0.
+//	t, err := gobptree.NewOrderStatisticTreeFunc[int, string](64, func(a, b int) int {
+//	    return cmp.Compare(b, a)
+//	})
+func NewOrderStatisticTreeFunc[K any, V any](order int, compare func(a, b K) int) (*Tree[K, V], error) {
+	t, err := NewTreeFunc[K, V](order, compare)
+	if err != nil {
+		return nil, err
+	}
+	t.counted = true
+	return t, nil
+}
+
+// Rank returns the number of keys in the tree that are less than key, which is
+// the index key has, or would have, in an ascending enumeration of the tree.
+// It is O(log n) for trees created by NewOrderStatisticTree or
+// NewOrderStatisticTreeFunc, and otherwise enumerates every key less than key.
+func (t *Tree[K, V]) Rank(key K) int {
+	if !t.counted {
+		var rank int
+		for k := range t.All() {
+			if t.compare(k, key) >= 0 {
+				break
+			}
+			rank++
+		}
+		return rank
+	}
+
+	root := t.lockRoot()
+	rank := t.countBefore(root, key, false)
+	root.unlock()
+	return rank
+}
+
+// Select returns the key-value pair at index i in an ascending enumeration of
+// the tree, so Select(0) returns the smallest key. When i is negative or not
+// less than the number of keys in the tree it returns false. It is O(log n)
+// for trees created by NewOrderStatisticTree or NewOrderStatisticTreeFunc, and
+// otherwise enumerates every key before the one it returns.
+func (t *Tree[K, V]) Select(i int) (K, V, bool) {
+	var key K
+	var value V
+
+	if i < 0 {
+		return key, value, false
+	}
+
+	if !t.counted {
+		for k, v := range t.All() {
+			if i == 0 {
+				return k, v, true
+			}
+			i--
+		}
+		return key, value, false
+	}
+
+	n := t.lockRoot()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		// Skip the children whose keys all come before index i.
+		index := 0
+		for index < len(parent.counts)-1 && i >= parent.counts[index] {
+			i -= parent.counts[index]
+			index++
+		}
+		child := parent.children[index]
+		child.lock()
+		parent.unlock()
+		n = child
+	}
+	l := n.(*leafNode[K, V])
+
+	var ok bool
+	if i < len(l.runts) {
+		key, value, ok = l.runts[i], l.values[i], true
+	}
+	l.unlock()
+	return key, value, ok
+}
+
+// CountRange returns the number of keys in the tree that are greater than or
+// equal to lo and less than or equal to hi. It is O(log n) for trees created
+// by NewOrderStatisticTree or NewOrderStatisticTreeFunc, and otherwise
+// enumerates every key in the range.
+func (t *Tree[K, V]) CountRange(lo, hi K) int {
+	if t.compare(lo, hi) > 0 {
+		return 0
+	}
+
+	if !t.counted {
+		var count int
+		for range t.Range(lo, hi, RangeOptions{}) {
+			count++
+		}
+		return count
+	}
+
+	// Holding the lock on the root while counting the keys before each bound
+	// prevents the tree from changing between the two descents.
+	root := t.lockRoot()
+	count := t.countBefore(root, hi, true) - t.countBefore(root, lo, false)
+	root.unlock()
+	return count
+}
+
+// countBefore returns the number of keys less than key, or when inclusive is
+// true, less than or equal to key, under the locked node n of a tree that
+// maintains counts. It descends from n to the leaf where key belongs, holding
+// the lock on each node below n only until the lock on its child has been
+// acquired. The lock on n remains held.
+func (t *Tree[K, V]) countBefore(n node[K, V], key K, inclusive bool) int {
+	var count int
+
+	top := n
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		index := searchLessThanOrEqualTo(key, parent.runts, t.compare)
+		// Every key under the children before the one where key belongs is
+		// less than key.
+		for _, c := range parent.counts[:index] {
+			count += c
+		}
+		child := parent.children[index]
+		child.lock()
+		if parent != top {
+			parent.unlock()
+		}
+		n = child
+	}
+	l := n.(*leafNode[K, V])
+
+	index, found := l.search(key, t.compare)
+	if found && inclusive {
+		index++
+	}
+	if l != top {
+		l.unlock()
+	}
+	return count + index
+}
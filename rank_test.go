@@ -0,0 +1,220 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+// ensureCounts ensures every count of every internal node of a tree that
+// maintains counts is the number of keys under the respective child.
+func ensureCounts[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}]) {
+	t.Helper()
+
+	var walk func(n node[K, interface{}]) int
+	walk = func(n node[K, interface{}]) int {
+		t.Helper()
+		i, ok := n.(*internalNode[K, interface{}])
+		if !ok {
+			return n.count()
+		}
+		if got, want := len(i.counts), len(i.children); got != want {
+			t.Errorf("length(counts) GOT: %v; WANT: %v", got, want)
+			return 0
+		}
+		var size int
+		for j, child := range i.children {
+			count := walk(child)
+			if got, want := i.counts[j], count; got != want {
+				t.Errorf("counts[%d] GOT: %v; WANT: %v", j, got, want)
+			}
+			size += count
+		}
+		return size
+	}
+	if got, want := walk(d.root), d.Len(); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestTreeOrderStatistics(t *testing.T) {
+	forEachKeyType(t, testTreeOrderStatistics[int32], testTreeOrderStatistics[int64], testTreeOrderStatistics[uint32], testTreeOrderStatistics[uint64], testTreeOrderStatistics[string])
+}
+
+func testTreeOrderStatistics[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	// ensureStatistics compares Rank, Select, and CountRange with the keys
+	// expected in the tree, in ascending order.
+	ensureStatistics := func(t *testing.T, d *Tree[K, interface{}], expected []int, keyCount int) {
+		t.Helper()
+
+		var rank int
+		for key := 0; key <= keyCount; key++ {
+			if got, want := d.Rank(k(key)), rank; got != want {
+				t.Errorf("Rank(%v) GOT: %v; WANT: %v", key, got, want)
+			}
+			if rank < len(expected) && expected[rank] == key {
+				rank++
+			}
+		}
+		for i, key := range expected {
+			if gotKey, gotValue, ok := d.Select(i); !ok || gotKey != k(key) || gotValue != key {
+				t.Errorf("Select(%v) GOT: %v, %v, %v; WANT: %v, %v, %v", i, gotKey, gotValue, ok, k(key), key, true)
+			}
+		}
+		for _, i := range []int{-1, len(expected), len(expected) + 1} {
+			if _, _, ok := d.Select(i); ok {
+				t.Errorf("Select(%v) GOT: %v; WANT: %v", i, ok, false)
+			}
+		}
+		for lo := 0; lo <= keyCount; lo += 7 {
+			for hi := lo - 1; hi <= keyCount; hi += 11 {
+				var want int
+				for _, key := range expected {
+					if lo <= key && key <= hi {
+						want++
+					}
+				}
+				if hi >= 0 {
+					if got := d.CountRange(k(lo), k(hi)); got != want {
+						t.Errorf("CountRange(%v, %v) GOT: %v; WANT: %v", lo, hi, got, want)
+					}
+				}
+			}
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewOrderStatisticTree[K, interface{}](4)
+		ensureStatistics(t, d, nil, 10)
+	})
+	t.Run("without counts", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		var expected []int
+		for i := 0; i < 100; i += 3 {
+			d.Insert(k(i), i)
+			expected = append(expected, i)
+		}
+		ensureStatistics(t, d, expected, 100)
+	})
+	t.Run("randomized", func(t *testing.T) {
+		const keyCount = 200
+
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			d, _ := NewOrderStatisticTree[K, interface{}](order)
+			m := make(map[int]bool)
+
+			for step := 0; step < 600; step++ {
+				v := r.Intn(keyCount)
+				switch r.Intn(8) {
+				case 0:
+					d.Delete(k(v))
+					delete(m, v)
+				case 1:
+					d.Update(k(v), func(interface{}, bool) interface{} { return v })
+					m[v] = true
+				case 2:
+					d.LoadOrStore(k(v), v)
+					m[v] = true
+				case 3:
+					remove := r.Intn(2) == 0
+					d.Compute(k(v), func(interface{}, bool) (interface{}, ComputeAction) {
+						if remove {
+							return nil, ComputeDelete
+						}
+						return v, ComputeStore
+					})
+					if remove {
+						delete(m, v)
+					} else {
+						m[v] = true
+					}
+				case 4:
+					hi := v + r.Intn(20)
+					d.DeleteRange(k(v), k(hi))
+					for i := v; i <= hi; i++ {
+						delete(m, i)
+					}
+				case 5:
+					keys := make([]K, 10)
+					for i := range keys {
+						key := r.Intn(keyCount)
+						keys[i] = k(key)
+						delete(m, key)
+					}
+					d.DeleteMany(keys)
+				case 6:
+					items := make([]int, 30)
+					for i := range items {
+						items[i] = r.Intn(keyCount)
+						m[items[i]] = true
+					}
+					d.InsertMany(func(yield func(K, interface{}) bool) {
+						for _, item := range items {
+							if !yield(k(item), item) {
+								return
+							}
+						}
+					})
+				default:
+					d.Insert(k(v), v)
+					m[v] = true
+				}
+
+				ensureCounts(t, d)
+				if step%20 == 0 {
+					var expected []int
+					for i := 0; i < keyCount; i++ {
+						if m[i] {
+							expected = append(expected, i)
+						}
+					}
+					ensureStatistics(t, d, expected, keyCount)
+					ensureStructure(t, d)
+				}
+				if t.Failed() {
+					t.Fatalf("ORDER: %d; STEP: %d", order, step)
+				}
+			}
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 500
+
+		d, _ := NewOrderStatisticTree[K, interface{}](4)
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := g; i < goroutines*perGoroutine; i += goroutines {
+					d.Insert(k(i), i)
+					if rank := d.Rank(k(i)); rank > i {
+						t.Errorf("Rank(%v) GOT: %v; WANT: <= %v", i, rank, i)
+					}
+				}
+				for i := g; i < goroutines*perGoroutine; i += 2 * goroutines {
+					d.Delete(k(i))
+					d.CountRange(k(0), k(i))
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		ensureCounts(t, d)
+		var expected []int
+		for i := 0; i < goroutines*perGoroutine; i++ {
+			if i%(2*goroutines) >= goroutines {
+				expected = append(expected, i)
+			}
+		}
+		for i, key := range expected {
+			if got, want := d.Rank(k(key)), i; got != want {
+				t.Errorf("Rank(%v) GOT: %v; WANT: %v", key, got, want)
+			}
+		}
+	})
+}
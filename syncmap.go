@@ -0,0 +1,87 @@
+package gobptree
+
+// The following methods provide the semantics of the methods of sync.Map with
+// the same names. Each is atomic with respect to every other operation on the
+// same key, because each holds the lock on the leaf node where the key belongs
+// from the time it observes the value of the key until it modifies the leaf.
+
+// LoadOrStore returns the existing value for key and true when key is in the
+// tree. Otherwise, it stores value for key, and returns value and false.
+func (t *Tree[K, V]) LoadOrStore(key K, value V) (V, bool) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	ln, ancestors := t.lockLeafForInsert(key)
+
+	index, found := ln.search(key, t.compare)
+	if found {
+		actual := ln.values[index]
+		t.unlockPath(ln, ancestors, 0)
+		return actual, true
+	}
+
+	ln.insertAt(index, key, value)
+	t.length.Add(1)
+	t.unlockPath(ln, ancestors, 1)
+	return value, false
+}
+
+// LoadAndDelete removes key from the tree, and returns its previous value and
+// true when key was in the tree. It is equivalent to Delete.
+func (t *Tree[K, V]) LoadAndDelete(key K) (V, bool) {
+	return t.Delete(key)
+}
+
+// Swap stores value for key, and returns the previous value and true when key
+// was in the tree, or the zero value of V and false when it was not. It is
+// equivalent to Insert.
+func (t *Tree[K, V]) Swap(key K, value V) (V, bool) {
+	return t.Insert(key, value)
+}
+
+// CompareAndSwap stores new for key when key is in the tree and its value is
+// equal to old, and returns whether it stored new. Like sync.Map, the values
+// are compared with the == operator, so CompareAndSwap panics when the value
+// of key and old are of the same type, and that type is not comparable.
+func (t *Tree[K, V]) CompareAndSwap(key K, old, new V) bool {
+	t.beginWrite()
+	defer t.endWrite()
+
+	var ln *leafNode[K, V]
+	var ancestors []ancestor[K, V]
+	if t.aggregator != nil || t.cow.Load() != nil {
+		// The summaries along the path change along with the value, and the
+		// leaf might be shared with a clone, in which case every node along
+		// the path is copied before the leaf is modified.
+		ln, ancestors = t.lockLeafForInsert(key)
+	} else {
+		ln = t.lockLeafForSearch(key)
+	}
+	defer t.unlockPath(ln, ancestors, 0)
+
+	index, found := ln.search(key, t.compare)
+	if !found || any(ln.values[index]) != any(old) {
+		return false
+	}
+	ln.values[index] = new
+	return true
+}
+
+// CompareAndDelete removes key from the tree when key is in the tree and its
+// value is equal to old, and returns whether it removed key. The values are
+// compared like CompareAndSwap compares them, and like Compute, it holds the
+// lock on every node from the root to the leaf node where key belongs until it
+// completes.
+func (t *Tree[K, V]) CompareAndDelete(key K, old V) bool {
+	var deleted bool
+
+	t.Compute(key, func(value V, ok bool) (V, ComputeAction) {
+		if !ok || any(value) != any(old) {
+			return value, ComputeSkip
+		}
+		deleted = true
+		return value, ComputeDelete
+	})
+
+	return deleted
+}
@@ -0,0 +1,152 @@
+package gobptree
+
+import (
+	"cmp"
+	"sync"
+	"testing"
+)
+
+func TestTreeSyncMap(t *testing.T) {
+	forEachKeyType(t, testTreeSyncMap[int32], testTreeSyncMap[int64], testTreeSyncMap[uint32], testTreeSyncMap[uint64], testTreeSyncMap[string])
+}
+
+func testTreeSyncMap[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureResult := func(t *testing.T, value interface{}, ok bool, wantValue interface{}, wantOK bool) {
+		t.Helper()
+		if value != wantValue || ok != wantOK {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, wantValue, wantOK)
+		}
+	}
+	ensureLen := func(t *testing.T, d *Tree[K, interface{}], want int) {
+		t.Helper()
+		if got := d.Len(); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	t.Run("LoadOrStore", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		value, ok := d.LoadOrStore(k(1), "first")
+		ensureResult(t, value, ok, "first", false)
+		value, ok = d.LoadOrStore(k(1), "second")
+		ensureResult(t, value, ok, "first", true)
+		value, ok = d.Search(k(1))
+		ensureResult(t, value, ok, "first", true)
+		ensureLen(t, d, 1)
+	})
+	t.Run("LoadAndDelete", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 20; i++ {
+			d.Insert(k(i), i)
+		}
+		value, ok := d.LoadAndDelete(k(7))
+		ensureResult(t, value, ok, 7, true)
+		value, ok = d.LoadAndDelete(k(7))
+		ensureResult(t, value, ok, nil, false)
+		value, ok = d.Search(k(7))
+		ensureResult(t, value, ok, nil, false)
+		ensureLen(t, d, 19)
+		ensureStructure(t, d)
+	})
+	t.Run("Swap", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		value, ok := d.Swap(k(1), "first")
+		ensureResult(t, value, ok, nil, false)
+		value, ok = d.Swap(k(1), "second")
+		ensureResult(t, value, ok, "first", true)
+		value, ok = d.Search(k(1))
+		ensureResult(t, value, ok, "second", true)
+		ensureLen(t, d, 1)
+	})
+	t.Run("CompareAndSwap", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		if got, want := d.CompareAndSwap(k(1), nil, "first"), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureLen(t, d, 0)
+
+		d.Insert(k(1), "first")
+		if got, want := d.CompareAndSwap(k(1), "other", "second"), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := d.CompareAndSwap(k(1), "first", "second"), true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		value, ok := d.Search(k(1))
+		ensureResult(t, value, ok, "second", true)
+	})
+	t.Run("CompareAndDelete", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 20; i++ {
+			d.Insert(k(i), i)
+		}
+		if got, want := d.CompareAndDelete(k(7), 8), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := d.CompareAndDelete(k(42), nil), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureLen(t, d, 20)
+		if got, want := d.CompareAndDelete(k(7), 7), true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		value, ok := d.Search(k(7))
+		ensureResult(t, value, ok, nil, false)
+		ensureLen(t, d, 19)
+		ensureStructure(t, d)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const keyCount = 200
+		const increments = 50
+
+		d, _ := NewTree[K, interface{}](4)
+
+		// Each go-routine increments every counter with a loop of
+		// CompareAndSwap, so an increment is only lost when the operations
+		// are not atomic.
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for n := 0; n < increments; n++ {
+					for i := 0; i < keyCount; i++ {
+						for {
+							value, _ := d.LoadOrStore(k(i), 0)
+							if d.CompareAndSwap(k(i), value, value.(int)+1) {
+								break
+							}
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		for i := 0; i < keyCount; i++ {
+			value, ok := d.Search(k(i))
+			ensureResult(t, value, ok, goroutines*increments, true)
+		}
+
+		// Only one go-routine may remove each key.
+		var deleted sync.Map
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func() {
+				defer wg.Done()
+				for i := 0; i < keyCount; i++ {
+					if value, ok := d.LoadAndDelete(k(i)); ok {
+						if _, loaded := deleted.LoadOrStore(i, value); loaded {
+							t.Errorf("KEY: %v; GOT: deleted twice", i)
+						}
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		ensureScan(t, d, k(0), nil)
+		ensureLen(t, d, 0)
+	})
+}
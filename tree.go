@@ -0,0 +1,1657 @@
+package gobptree
+
+import (
+	"cmp"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+// searchGreaterThanOrEqualTo returns the index of the first value from values
+// that is greater than or equal to key, as ordered by compare.
+func searchGreaterThanOrEqualTo[K any](key K, values []K, compare func(a, b K) int) int {
+	var lo int
+
+	hi := len(values)
+	if hi <= 1 {
+		return 0
+	}
+	hi--
+
+loop:
+	m := (lo + hi) >> 1
+	c := compare(key, values[m])
+	if c < 0 {
+		if hi = m; lo < hi {
+			goto loop
+		}
+		return lo
+	}
+	if c > 0 {
+		if lo = m + 1; lo < hi {
+			goto loop
+		}
+		return lo
+	}
+	return m
+}
+
+// searchLessThanOrEqualTo returns the index of the first value from values
+// that is less than or equal to key, as ordered by compare.
+func searchLessThanOrEqualTo[K any](key K, values []K, compare func(a, b K) int) int {
+	index := searchGreaterThanOrEqualTo(key, values, compare)
+	// convert result to less than or equal to
+	if index == len(values) || compare(key, values[index]) < 0 {
+		if index > 0 {
+			return index - 1
+		}
+	}
+	return index
+}
+
+// node represents either an internal or a leaf node for a Tree.
+type node[K any, V any] interface {
+	absorbRight(node[K, V])
+	adoptFromLeft(node[K, V])
+	adoptFromRight(node[K, V])
+	clone(*cowContext) node[K, V]
+	computeKey(int, K, func(a, b K) int, func(V, bool) (V, ComputeAction)) (int, bool)
+	count() int
+	isInternal() bool
+	lock()
+	maybeSplit(order int) (node[K, V], node[K, V])
+	owner() *cowContext
+	size() int
+	smallest() K
+	summary(*Aggregator[V]) V
+	unlock()
+}
+
+// internalNode represents an internal node for a Tree.
+type internalNode[K any, V any] struct {
+	runts    []K
+	children []node[K, V]
+	counts   []int // number of keys under each child, or nil when the tree does not maintain counts
+	// summary of the values under each child, or nil when the tree does not
+	// maintain summaries
+	summaries  []V
+	aggregator *Aggregator[V]
+	cow        *cowContext // the tree that may modify this node in place
+	mutex      sync.Mutex
+}
+
+func (left *internalNode[K, V]) absorbRight(sibling node[K, V]) {
+	right := sibling.(*internalNode[K, V])
+	left.runts = append(left.runts, right.runts...)
+	left.children = append(left.children, right.children...)
+	if left.counts != nil {
+		left.counts = append(left.counts, right.counts...)
+	}
+	if left.summaries != nil {
+		left.summaries = append(left.summaries, right.summaries...)
+	}
+
+	// Perhaps following are not strictly needed, but de-allocate slices.
+	right.runts = nil
+	right.children = nil
+	right.counts = nil
+	right.summaries = nil
+}
+
+func (right *internalNode[K, V]) adoptFromLeft(sibling node[K, V]) {
+	left := sibling.(*internalNode[K, V])
+
+	var zero K
+	right.runts = append(right.runts, zero)
+	right.children = append(right.children, nil)
+	copy(right.runts[1:], right.runts[0:])
+	copy(right.children[1:], right.children[0:])
+
+	index := len(left.runts) - 1
+	right.runts[0] = left.runts[index]
+	right.children[0] = left.children[index]
+
+	if right.counts != nil {
+		right.counts = append(right.counts, 0)
+		copy(right.counts[1:], right.counts[0:])
+		right.counts[0] = left.counts[index]
+		left.counts = left.counts[:index]
+	}
+	if right.summaries != nil {
+		var zeroV V
+		right.summaries = append(right.summaries, zeroV)
+		copy(right.summaries[1:], right.summaries[0:])
+		right.summaries[0] = left.summaries[index]
+		left.summaries[index] = zeroV
+		left.summaries = left.summaries[:index]
+	}
+
+	left.runts = left.runts[:index]
+	left.children = left.children[:index]
+}
+
+func (left *internalNode[K, V]) adoptFromRight(sibling node[K, V]) {
+	right := sibling.(*internalNode[K, V])
+
+	left.runts = append(left.runts, right.runts[0])
+	left.children = append(left.children, right.children[0])
+
+	copy(right.runts[0:], right.runts[1:])
+	copy(right.children[0:], right.children[1:])
+
+	index := len(right.runts) - 1
+	right.runts = right.runts[:index]
+	right.children = right.children[:index]
+
+	if left.counts != nil {
+		left.counts = append(left.counts, right.counts[0])
+		copy(right.counts[0:], right.counts[1:])
+		right.counts = right.counts[:index]
+	}
+	if left.summaries != nil {
+		var zeroV V
+		left.summaries = append(left.summaries, right.summaries[0])
+		copy(right.summaries[0:], right.summaries[1:])
+		right.summaries[index] = zeroV
+		right.summaries = right.summaries[:index]
+	}
+}
+
+func (i *internalNode[K, V]) count() int { return len(i.runts) }
+
+// lockChildForDelete locks and returns the child where key belongs, along with
+// its index. When the child holds no more than minSize keys or children, it is
+// first restored by adopting a single node from one of its siblings, or by
+// merging it with a sibling, so removing a key from under the child never
+// leaves it too small for this node to restore afterwards. The child returned
+// is the sibling that absorbed it when the child was merged into its left
+// sibling.
+func (i *internalNode[K, V]) lockChildForDelete(minSize int, key K, compare func(a, b K) int) (node[K, V], int) {
+	index := searchLessThanOrEqualTo(key, i.runts, compare)
+	child := i.mutableChild(index)
+	child.lock()
+	if child.count() > minSize || len(i.children) == 1 {
+		return child, index
+	}
+
+	// rebalanceChild locks the left sibling before the child. No other
+	// operation may modify the child while this node remains locked.
+	child.unlock()
+	i.rebalanceChild(index, minSize)
+
+	// The runts of this node changed when the child adopted from its left
+	// sibling or merged with a sibling, so key might now belong under another
+	// child. rebalanceChild replaced every sibling it modified with a mutable
+	// copy.
+	index = searchLessThanOrEqualTo(key, i.runts, compare)
+	child = i.children[index]
+	child.lock()
+	return child, index
+}
+
+// rebalanceChild restores the child at index, which has become too small, or
+// which Delete is about to remove a key from, by adopting a single node from
+// one of its siblings, or when neither sibling can spare one, by merging it
+// with a sibling. It returns whether this node has become too small as a
+// result.
+//
+// This node must be locked, but the child must not be. So that an operation
+// holding the lock on a leaf only ever waits for the lock on a leaf after it,
+// like a cursor scanning in ascending order, the left sibling is locked before
+// the child, and the child before the right sibling.
+//
+// DeleteRange might leave a node with a single child, which has no sibling to
+// adopt from or merge with, so such a node reports that it is too small, and
+// its parent restores it instead.
+func (i *internalNode[K, V]) rebalanceChild(index, minSize int) bool {
+	if len(i.children) == 1 {
+		return true
+	}
+
+	var leftSibling, rightSibling node[K, V]
+	var leftCount, rightCount int
+
+	if index > 0 {
+		leftSibling = i.mutableChild(index - 1)
+		leftSibling.lock()
+		defer leftSibling.unlock()
+		leftCount = leftSibling.count()
+	}
+
+	child := i.mutableChild(index)
+	child.lock()
+	defer child.unlock()
+
+	if index < len(i.runts)-1 {
+		// try right sibling first to encourage left leaning trees
+		rightSibling = i.mutableChild(index + 1)
+		rightSibling.lock()
+		defer rightSibling.unlock()
+		if rightCount = rightSibling.count(); rightCount > minSize {
+			child.adoptFromRight(rightSibling)
+			i.runts[index+1] = rightSibling.smallest()
+			if i.counts != nil {
+				i.counts[index] = child.size()
+				i.counts[index+1] = rightSibling.size()
+			}
+			i.summarize(index)
+			i.summarize(index + 1)
+			return false
+		}
+	}
+	// POST: If right, it is exactly minimum size.
+
+	if leftCount > minSize {
+		// try left sibling
+		child.adoptFromLeft(leftSibling)
+		// The adopted runt is smaller than the runt that used to route to the
+		// child, so this node's runt for the child must follow it.
+		i.runts[index] = child.smallest()
+		if i.counts != nil {
+			i.counts[index-1] = leftSibling.size()
+			i.counts[index] = child.size()
+		}
+		i.summarize(index - 1)
+		i.summarize(index)
+		return false
+	}
+	// POST: If left, it is exactly minimum size.
+
+	// POST: Could not adopt a single node from either side, because either
+	// child is left or right edge and has no siblings to its left or right, or
+	// the siblings it does have each only has the minimum number of children.
+
+	if leftCount > 0 {
+		leftSibling.absorbRight(child)
+		i.mergeAggregates(index - 1)
+		copy(i.runts[index:], i.runts[index+1:])
+		i.runts = i.runts[:len(i.runts)-1]
+		copy(i.children[index:], i.children[index+1:])
+		i.children = i.children[:len(i.children)-1]
+		// This node has one fewer children.
+		return len(i.runts) < minSize
+	}
+
+	// When right has no children, then should not be in a position where left
+	// also has no children.
+	if rightCount == 0 {
+		panic("both left and right siblings have no children")
+	}
+
+	child.absorbRight(rightSibling)
+	i.mergeAggregates(index)
+	copy(i.runts[index+1:], i.runts[index+2:])
+	i.runts = i.runts[:len(i.runts)-1]
+	copy(i.children[index+1:], i.children[index+2:])
+	i.children = i.children[:len(i.children)-1]
+	// This node has one fewer children.
+	return len(i.runts) < minSize
+}
+
+// mergeAggregates combines the count and summary of the child after index
+// with those of the child at index, and removes the count and summary of the
+// child after index, after the child at index has absorbed its right sibling.
+func (i *internalNode[K, V]) mergeAggregates(index int) {
+	if i.counts != nil {
+		i.counts[index] += i.counts[index+1]
+		copy(i.counts[index+1:], i.counts[index+2:])
+		i.counts = i.counts[:len(i.counts)-1]
+	}
+	if i.summaries != nil {
+		i.summaries[index] = i.aggregator.Combine(i.summaries[index], i.summaries[index+1])
+		copy(i.summaries[index+1:], i.summaries[index+2:])
+		var zeroV V
+		i.summaries[len(i.summaries)-1] = zeroV
+		i.summaries = i.summaries[:len(i.summaries)-1]
+	}
+}
+
+// insertSibling inserts right, the new sibling of the child at index after it
+// was split, to the right of the child.
+func (i *internalNode[K, V]) insertSibling(index int, right node[K, V]) {
+	var zero K
+	i.runts = append(i.runts, zero)
+	i.children = append(i.children, nil)
+	copy(i.runts[index+2:], i.runts[index+1:])
+	copy(i.children[index+2:], i.children[index+1:])
+	i.children[index+1] = right
+	i.runts[index+1] = right.smallest()
+
+	if i.counts != nil {
+		moved := right.size()
+		i.counts = append(i.counts, 0)
+		copy(i.counts[index+2:], i.counts[index+1:])
+		i.counts[index] -= moved
+		i.counts[index+1] = moved
+	}
+	if i.summaries != nil {
+		var zeroV V
+		i.summaries = append(i.summaries, zeroV)
+		copy(i.summaries[index+2:], i.summaries[index+1:])
+		i.summarize(index)
+		i.summarize(index + 1)
+	}
+}
+
+func (i *internalNode[K, V]) isInternal() bool { return true }
+
+func (i *internalNode[K, V]) lock() { i.mutex.Lock() }
+
+// maybeSplit splits the node, giving half of its values to its new sibling,
+// when the node is too full to accept any more values. A node may hold more
+// than order values after absorbing its right sibling during a deletion, in
+// which case the sibling receives every value past the first half.
+//
+// NOTE: This function assumes the tree's order is a multiple of 2, which must
+// be guarded for at tree instantiation time.
+func (i *internalNode[K, V]) maybeSplit(order int) (node[K, V], node[K, V]) {
+	if len(i.runts) < order {
+		return i, nil
+	}
+	newNodeRunts := order >> 1
+	sibling := &internalNode[K, V]{
+		runts:      make([]K, 0, order),
+		children:   make([]node[K, V], 0, order),
+		aggregator: i.aggregator,
+		cow:        i.cow,
+	}
+	// Right half of this node moves to sibling.
+	sibling.runts = append(sibling.runts, i.runts[newNodeRunts:]...)
+	sibling.children = append(sibling.children, i.children[newNodeRunts:]...)
+	if i.counts != nil {
+		sibling.counts = append(make([]int, 0, order), i.counts[newNodeRunts:]...)
+		i.counts = i.counts[:newNodeRunts]
+	}
+	if i.summaries != nil {
+		sibling.summaries = append(make([]V, 0, order), i.summaries[newNodeRunts:]...)
+		clear(i.summaries[newNodeRunts:])
+		i.summaries = i.summaries[:newNodeRunts]
+	}
+	// Clear the runts and pointers from the original node.
+	clear(i.children[newNodeRunts:])
+	i.runts = i.runts[:newNodeRunts]
+	i.children = i.children[:newNodeRunts]
+	return i, sibling
+}
+
+// size returns the number of keys under this node. It is only valid when the
+// tree maintains counts.
+func (i *internalNode[K, V]) size() int {
+	var size int
+	for _, count := range i.counts {
+		size += count
+	}
+	return size
+}
+
+func (i *internalNode[K, V]) smallest() K {
+	if len(i.runts) == 0 {
+		panic("internal node has no children")
+	}
+	return i.runts[0]
+}
+
+func (i *internalNode[K, V]) unlock() { i.mutex.Unlock() }
+
+// leafNode represents a leaf node for a Tree.
+type leafNode[K any, V any] struct {
+	runts  []K
+	values []V
+	next   *leafNode[K, V]                // points to next leaf to allow enumeration
+	prev   atomic.Pointer[leafNode[K, V]] // points to previous leaf to allow reverse enumeration
+	cow    *cowContext                    // the tree that may modify this leaf in place
+	mutex  sync.Mutex
+}
+
+func (left *leafNode[K, V]) absorbRight(sibling node[K, V]) {
+	right := sibling.(*leafNode[K, V])
+	if left.cow == nil {
+		// Only leaves that belong to no context are linked.
+		if left.next != right {
+			// Superfluous check
+			panic("cannot merge leaf with sibling other than next sibling")
+		}
+		left.next = right.next
+		if left.next != nil {
+			left.next.prev.Store(left)
+		}
+	}
+	left.runts = append(left.runts, right.runts...)
+	left.values = append(left.values, right.values...)
+
+	// Perhaps following are not strictly needed, but de-allocate slices and
+	// release pointers.
+	right.runts = nil
+	right.values = nil
+	right.next = nil
+	right.prev.Store(nil)
+}
+
+func (right *leafNode[K, V]) adoptFromLeft(sibling node[K, V]) {
+	left := sibling.(*leafNode[K, V])
+
+	var zeroK K
+	var zeroV V
+	right.runts = append(right.runts, zeroK)
+	right.values = append(right.values, zeroV)
+	copy(right.runts[1:], right.runts[0:])
+	copy(right.values[1:], right.values[0:])
+
+	index := len(left.runts) - 1
+	right.runts[0] = left.runts[index]
+	right.values[0] = left.values[index]
+
+	left.runts = left.runts[:index]
+	left.values = left.values[:index]
+}
+
+func (left *leafNode[K, V]) adoptFromRight(sibling node[K, V]) {
+	right := sibling.(*leafNode[K, V])
+	left.runts = append(left.runts, right.runts[0])
+	left.values = append(left.values, right.values[0])
+	copy(right.runts[0:], right.runts[1:])
+	copy(right.values[0:], right.values[1:])
+	index := len(right.runts) - 1
+	right.runts = right.runts[:index]
+	right.values = right.values[:index]
+}
+
+func (l *leafNode[K, V]) count() int { return len(l.runts) }
+
+// deleteKey removes key from this leaf, and returns the value that was
+// associated with key, whether key was found, and whether this leaf has become
+// too small as a result.
+func (l *leafNode[K, V]) deleteKey(minSize int, key K, compare func(a, b K) int) (V, bool, bool) {
+	var value V
+	index := searchGreaterThanOrEqualTo(key, l.runts, compare)
+	if index == len(l.runts) || compare(key, l.runts[index]) != 0 {
+		return value, false, false
+	}
+	value = l.values[index]
+	copy(l.runts[index:], l.runts[index+1:])
+	copy(l.values[index:], l.values[index+1:])
+	l.runts = l.runts[:len(l.runts)-1]
+	l.values = l.values[:len(l.values)-1]
+	return value, true, len(l.runts) < minSize
+}
+
+func (l *leafNode[K, V]) isInternal() bool { return false }
+
+func (l *leafNode[K, V]) lock() { l.mutex.Lock() }
+
+// maybeSplit splits the node, giving half of its values to its new sibling,
+// when the node is too full to accept any more values. A node may hold more
+// than order values after absorbing its right sibling during a deletion, in
+// which case the sibling receives every value past the first half.
+//
+// NOTE: This function assumes the tree's order is a multiple of 2, which must
+// be guarded for at tree instantiation time.
+func (l *leafNode[K, V]) maybeSplit(order int) (node[K, V], node[K, V]) {
+	if len(l.runts) < order {
+		return l, nil
+	}
+	newNodeRunts := order >> 1
+	sibling := &leafNode[K, V]{
+		runts:  make([]K, 0, order),
+		values: make([]V, 0, order),
+		cow:    l.cow,
+	}
+	// Right half of this node moves to sibling.
+	sibling.runts = append(sibling.runts, l.runts[newNodeRunts:]...)
+	sibling.values = append(sibling.values, l.values[newNodeRunts:]...)
+	// Clear the runts and pointers from the original node.
+	clear(l.values[newNodeRunts:])
+	l.runts = l.runts[:newNodeRunts]
+	l.values = l.values[:newNodeRunts]
+	if l.cow == nil {
+		// Only leaves that belong to no context are linked. A reverse cursor
+		// on the next leaf reaches the sibling through its prev pointer
+		// without the lock on this leaf, so the sibling is filled before it is
+		// linked, and locked until it is linked in both directions.
+		sibling.lock()
+		sibling.next = l.next
+		sibling.prev.Store(l)
+		if sibling.next != nil {
+			sibling.next.prev.Store(sibling)
+		}
+		l.next = sibling
+		sibling.unlock()
+	}
+	return l, sibling
+}
+
+// search returns the index of key in this leaf and true, or when key is not in
+// this leaf, the index where key belongs and false.
+func (l *leafNode[K, V]) search(key K, compare func(a, b K) int) (int, bool) {
+	index := searchGreaterThanOrEqualTo(key, l.runts, compare)
+	if index < len(l.runts) {
+		if c := compare(key, l.runts[index]); c == 0 {
+			return index, true
+		} else if c > 0 {
+			// Key belongs after every key in this leaf.
+			index++
+		}
+	}
+	return index, false
+}
+
+func (l *leafNode[K, V]) size() int { return len(l.runts) }
+
+func (l *leafNode[K, V]) smallest() K {
+	if len(l.runts) == 0 {
+		panic("leaf node has no children")
+	}
+	return l.runts[0]
+}
+
+func (l *leafNode[K, V]) unlock() { l.mutex.Unlock() }
+
+// Tree is a B+Tree of elements using keys of type K, each associated with a
+// value of type V. Keys are ordered by the tree's comparison function.
+type Tree[K any, V any] struct {
+	root      node[K, V]
+	rootMutex sync.RWMutex // guards the root and epoch pointers, but not the root node
+	epoch     *epoch[K, V] // nil until a cursor records the epoch of the root
+	compare   func(a, b K) int
+	order     int
+	length    atomic.Int64 // number of key-value pairs in the tree
+	counted   bool         // when true internal nodes maintain the number of keys under each child
+	// when not nil internal nodes maintain the summary of the values under
+	// each child
+	aggregator *Aggregator[V]
+	// the context of the nodes this tree may modify in place, which is nil
+	// while the leaves of the tree are linked
+	cow atomic.Pointer[cowContext]
+	// true once the tree has shared its nodes with a clone or snapshot, after
+	// which its leaves are never linked again
+	cloned        atomic.Bool
+	versions      atomic.Int64 // number of versions taken by View that have readers
+	cursors       atomic.Int64 // number of cursors that might hold a leaf node
+	relinkPending atomic.Bool  // true when the next operation that modifies the tree links its leaves
+	// held shared by every operation that modifies the tree, and exclusively
+	// while the tree is cloned, snapshotted, or a version is taken
+	writeMutex sync.RWMutex
+	// the version most recently taken by View, until it has no readers
+	view      *ReadView[K, V]
+	viewMutex sync.Mutex  // guards view, and the readers of every version
+	viewFresh atomic.Bool // true until the tree is modified after view was taken
+}
+
+// NewTree returns a newly initialized Tree of the specified order, whose keys
+// are in ascending order.
+func NewTree[K cmp.Ordered, V any](order int) (*Tree[K, V], error) {
+	return NewTreeFunc[K, V](order, cmp.Compare[K])
+}
+
+// NewTreeFunc returns a newly initialized Tree of the specified order, whose
+// keys are ordered by compare. Like the comparison functions used by the
+// slices package, compare must return a negative number when a < b, a positive
+// number when a > b, and zero when a and b are equal, and it must describe a
+// strict weak ordering of the keys.
+//
+// When the library is built with the gobptree_debug build tag and K is an
+// interface type, the tree panics when it compares keys of different dynamic
+// types, rather than silently misplacing them.
+//
+//	// Case-insensitive string keys in descending order.
+//	t, err := gobptree.NewTreeFunc[string, int](64, func(a, b string) int {
+//	    return strings.Compare(strings.ToLower(b), strings.ToLower(a))
+//	})
+func NewTreeFunc[K any, V any](order int, compare func(a, b K) int) (*Tree[K, V], error) {
+	if err := checkOrder(order); err != nil {
+		return nil, err
+	}
+	if compare == nil {
+		return nil, errors.New("cannot create tree without a comparison function")
+	}
+	if debug && reflect.TypeOf((*K)(nil)).Elem().Kind() == reflect.Interface {
+		// Keys of different dynamic types can only meet when K is an interface
+		// type.
+		unchecked := compare
+		compare = func(a, b K) int {
+			ensureSameKeyType(a, b)
+			return unchecked(a, b)
+		}
+	}
+	return &Tree[K, V]{
+		root: &leafNode[K, V]{
+			runts:  make([]K, 0, order),
+			values: make([]V, 0, order),
+		},
+		compare: compare,
+		order:   order,
+	}, nil
+}
+
+// beginWrite acquires the write mutex shared for an operation that modifies
+// the tree, and marks the version taken by View as no longer fresh.
+func (t *Tree[K, V]) beginWrite() {
+	if t.relinkPending.Load() {
+		t.writeMutex.Lock()
+		t.relink()
+		t.writeMutex.Unlock()
+	}
+	t.writeMutex.RLock()
+	// Only storing when the version is fresh keeps writers from contending
+	// for the flag.
+	if t.viewFresh.Load() {
+		t.viewFresh.Store(false)
+	}
+}
+
+// endWrite releases the write mutex acquired by beginWrite.
+func (t *Tree[K, V]) endWrite() { t.writeMutex.RUnlock() }
+
+// Delete removes the key-value pair from the tree, and returns the value that
+// was associated with key and true, or the zero value of V and false when key
+// was not in the tree.
+func (t *Tree[K, V]) Delete(key K) (V, bool) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	ln, ancestors := t.lockLeafForDelete(key)
+	value, deleted, _ := ln.deleteKey(t.order, key, t.compare)
+	var inserted int
+	if deleted {
+		inserted = -1
+		t.length.Add(-1)
+	}
+	t.unlockPath(ln, ancestors, inserted)
+	return value, deleted
+}
+
+// Insert inserts the key-value pair into the tree, replacing the existing value
+// with the new value if the key is already in the tree. It returns the value it
+// replaced and true, or the zero value of V and false when key was not in the
+// tree.
+func (t *Tree[K, V]) Insert(key K, value V) (V, bool) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	ln, ancestors := t.lockLeafForInsert(key)
+	previous, replaced := ln.insert(key, value, t.compare)
+	var inserted int
+	if !replaced {
+		inserted = 1
+		t.length.Add(1)
+	}
+	t.unlockPath(ln, ancestors, inserted)
+	return previous, replaced
+}
+
+// insert stores the key-value pair in the leaf, replacing the existing value
+// when the key is already in the leaf, and returns the value it replaced and
+// true, or the zero value of V and false when the key is new.
+func (l *leafNode[K, V]) insert(key K, value V, compare func(a, b K) int) (V, bool) {
+	var previous V
+
+	// When the new value will become the first element in a leaf, which is only
+	// possible for an empty tree, or when new key comes after final leaf runt,
+	// a simple append will suffice.
+	if len(l.runts) == 0 || compare(key, l.runts[len(l.runts)-1]) > 0 {
+		l.runts = append(l.runts, key)
+		l.values = append(l.values, value)
+		return previous, false
+	}
+
+	index := searchGreaterThanOrEqualTo(key, l.runts, compare)
+
+	if compare(key, l.runts[index]) == 0 {
+		// When the key matches the runt, merely need to update the value.
+		previous = l.values[index]
+		l.values[index] = value
+		return previous, true
+	}
+
+	l.insertAt(index, key, value)
+	return previous, false
+}
+
+// insertAt makes room for and inserts the new key-value pair into the leaf at
+// the specified index.
+func (l *leafNode[K, V]) insertAt(index int, key K, value V) {
+	// Append zero values to make room in arrays
+	var zeroK K
+	var zeroV V
+	l.runts = append(l.runts, zeroK)
+	l.values = append(l.values, zeroV)
+	// Shift elements to the right to make room for new data
+	copy(l.runts[index+1:], l.runts[index:])
+	copy(l.values[index+1:], l.values[index:])
+	// Store the new data
+	l.runts[index] = key
+	l.values[index] = value
+}
+
+// lockRoot locks and returns the root node of the tree. The root is replaced
+// while its lock is held, either when it splits during an insertion or when it
+// has a single child after a deletion, so after acquiring the lock this
+// ensures the node is still the root, and starts over when it is not.
+func (t *Tree[K, V]) lockRoot() node[K, V] {
+	for {
+		t.rootMutex.RLock()
+		n := t.root
+		t.rootMutex.RUnlock()
+
+		n.lock()
+
+		t.rootMutex.RLock()
+		current := t.root
+		t.rootMutex.RUnlock()
+
+		if n == current {
+			return n
+		}
+		n.unlock()
+	}
+}
+
+// setRoot replaces the root node of the tree. The caller must hold the lock
+// on the current root node.
+func (t *Tree[K, V]) setRoot(n node[K, V]) {
+	t.rootMutex.Lock()
+	t.root = n
+	t.rootMutex.Unlock()
+}
+
+// lockLeafForInsert descends from the root of the tree to the leaf node where
+// key belongs, pre-emptively splitting every full node along the way so that
+// the lock on each parent node may be released before visiting its child. It
+// returns the leaf node, which remains locked.
+//
+// When the tree maintains counts or summaries, the lock on each internal node
+// along the path is instead held, because its count and summary for the child
+// on the path change when the leaf is modified. Those nodes are returned as
+// ancestors, which the caller releases with unlockPath after it modifies the
+// leaf.
+func (t *Tree[K, V]) lockLeafForInsert(key K) (*leafNode[K, V], []ancestor[K, V]) {
+	ln, _, _, ancestors := t.lockLeafForInsertBounded(key)
+	return ln, ancestors
+}
+
+// ancestor is an internal node held locked along the path from the root of a
+// tree that maintains counts or summaries to a leaf node, along with the index
+// of its child on that path.
+type ancestor[K any, V any] struct {
+	node  *internalNode[K, V]
+	index int
+}
+
+// unlockPath adds the number of keys inserted into the locked leaf node ln,
+// which is negative when keys were removed, to the count of every ancestor
+// along the path to it, recomputes the summary of every ancestor from the
+// bottom of the path up, and releases the lock on the leaf and each of its
+// ancestors.
+func (t *Tree[K, V]) unlockPath(ln *leafNode[K, V], ancestors []ancestor[K, V], inserted int) {
+	for j := len(ancestors) - 1; j >= 0; j-- {
+		a := ancestors[j]
+		if a.node.counts != nil {
+			a.node.counts[a.index] += inserted
+		}
+		a.node.summarize(a.index)
+	}
+	ln.unlock()
+	for _, a := range ancestors {
+		a.node.unlock()
+	}
+}
+
+// holdsAncestors returns whether operations that modify a leaf node must hold
+// the lock on every internal node along the path to it, because the tree
+// maintains counts or summaries.
+func (t *Tree[K, V]) holdsAncestors() bool {
+	return t.counted || t.aggregator != nil
+}
+
+// newRoot returns a new internal node to become the root of the tree, whose
+// children are left and right, after the former root split into them. The
+// runt of left is leftRunt, which might be less than the smallest key of left
+// when a key less than it is about to be inserted.
+func (t *Tree[K, V]) newRoot(leftRunt K, left, right node[K, V]) *internalNode[K, V] {
+	root := &internalNode[K, V]{
+		runts:      []K{leftRunt, right.smallest()},
+		children:   []node[K, V]{left, right},
+		aggregator: t.aggregator,
+		cow:        t.cow.Load(),
+	}
+	if t.counted {
+		root.counts = []int{left.size(), right.size()}
+	}
+	if t.aggregator != nil {
+		root.summaries = []V{left.summary(t.aggregator), right.summary(t.aggregator)}
+	}
+	return root
+}
+
+// lockLeafForInsertBounded behaves like lockLeafForInsert, but also returns
+// the runt that routes keys to the node after the leaf, and false when the
+// leaf is the final leaf of the tree. Every key greater than or equal to key
+// and less than that runt belongs in the leaf for as long as its lock is held,
+// because the runt that bounds a leaf is only lowered while the leaf is
+// locked.
+func (t *Tree[K, V]) lockLeafForInsertBounded(key K) (*leafNode[K, V], K, bool, []ancestor[K, V]) {
+	var hi K
+	var bounded bool
+	var ancestors []ancestor[K, V]
+
+	n := t.mutableRoot(t.lockRoot())
+
+	// Split the root node when required. Regardless of whether the root is an
+	// internal or a leaf node, the root shall become an internal node.
+	if left, right := n.maybeSplit(t.order); right != nil {
+		leftSmallest := left.smallest()
+		if t.compare(key, leftSmallest) < 0 {
+			leftSmallest = key
+		}
+		rightSmallest := right.smallest()
+		root := t.newRoot(leftSmallest, left, right)
+		// Lock the new root before publishing it, so no other operation may
+		// descend to the new sibling before this one does.
+		root.lock()
+		t.setRoot(root)
+		// Decide whether we need to descend left or right.
+		index := 0
+		if t.compare(key, rightSmallest) >= 0 {
+			right.lock()
+			n.unlock() // unlock the left, since same node
+			n = right
+			index = 1
+		} else {
+			hi, bounded = rightSmallest, true
+		}
+		if t.holdsAncestors() {
+			ancestors = append(ancestors, ancestor[K, V]{root, index})
+		} else {
+			root.unlock()
+		}
+	}
+
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		index := searchLessThanOrEqualTo(key, parent.runts, t.compare)
+
+		child := parent.mutableChild(index)
+		child.lock()
+
+		if index == 0 {
+			if smallest := child.smallest(); t.compare(key, smallest) < 0 {
+				// preemptively update smallest value
+				parent.runts[0] = key
+			}
+		}
+
+		// Split the internal node when required.
+		if _, right := child.maybeSplit(t.order); right != nil {
+			// Insert sibling to the right of current node.
+			parent.insertSibling(index, right)
+			// Decide whether we need to descend left or right.
+			if t.compare(key, parent.runts[index+1]) >= 0 {
+				right.lock()   // grab lock on its new sibling
+				child.unlock() // release lock on child
+				child = right  // descend to newly created sibling
+				index++
+			}
+		}
+
+		// The runt of the next child bounds every node below this one, and is
+		// never looser than the bound found above this node.
+		if index < len(parent.runts)-1 {
+			hi, bounded = parent.runts[index+1], true
+		}
+
+		// POST: tail end recursion to intended child
+		if t.holdsAncestors() {
+			ancestors = append(ancestors, ancestor[K, V]{parent, index})
+		} else {
+			parent.unlock() // release lock on this node before go to child locked above
+		}
+		n = child
+	}
+
+	return n.(*leafNode[K, V]), hi, bounded, ancestors
+}
+
+// lockLeafForDelete descends from the root of the tree to the leaf node where
+// key belongs, pre-emptively restoring every node along the way that might
+// become too small when the key is removed, so that the lock on each parent
+// node may be released before visiting its child, like lockLeafForInsert. A
+// root left with a single child is replaced by that child along the way. It
+// returns the leaf node, which remains locked, and like lockLeafForInsert, the
+// ancestors of the leaf when the tree maintains counts or summaries.
+func (t *Tree[K, V]) lockLeafForDelete(key K) (*leafNode[K, V], []ancestor[K, V]) {
+	ln, _, _, ancestors := t.lockLeafForDeleteBounded(key)
+	return ln, ancestors
+}
+
+// lockLeafForDeleteBounded behaves like lockLeafForDelete, but also returns
+// the runt that routes keys to the node after the leaf, and false when the
+// leaf is the final leaf of the tree, like lockLeafForInsertBounded.
+func (t *Tree[K, V]) lockLeafForDeleteBounded(key K) (*leafNode[K, V], K, bool, []ancestor[K, V]) {
+	var hi K
+	var bounded bool
+	var ancestors []ancestor[K, V]
+
+	n := t.mutableRoot(t.lockRoot())
+	isRoot := true
+
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		child, index := parent.lockChildForDelete(t.order, key, t.compare)
+
+		if isRoot && len(parent.children) == 1 {
+			// Root has outlived its usefulness when it has only a single
+			// child.
+			t.setRoot(child)
+			parent.unlock()
+			n = child
+			continue
+		}
+		isRoot = false
+
+		// The runt of the next child bounds every node below this one, and is
+		// never looser than the bound found above this node.
+		if index < len(parent.runts)-1 {
+			hi, bounded = parent.runts[index+1], true
+		}
+
+		// POST: tail end recursion to intended child
+		if t.holdsAncestors() {
+			ancestors = append(ancestors, ancestor[K, V]{parent, index})
+		} else {
+			parent.unlock() // release lock on this node before go to child locked above
+		}
+		n = child
+	}
+
+	return n.(*leafNode[K, V]), hi, bounded, ancestors
+}
+
+// lockLeafForSearch descends from the root of the tree to the leaf node where
+// key belongs, holding the lock on each node only until the lock on its child
+// has been acquired. It returns the leaf node, which remains locked.
+func (t *Tree[K, V]) lockLeafForSearch(key K) *leafNode[K, V] {
+	n := t.lockRoot()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		child := parent.children[searchLessThanOrEqualTo(key, parent.runts, t.compare)]
+		child.lock()
+		parent.unlock()
+		n = child
+	}
+	return n.(*leafNode[K, V])
+}
+
+// lockLeftmostLeaf descends from the root of the tree to its first leaf node,
+// holding the lock on each node only until the lock on its child has been
+// acquired. It returns the leaf node, which remains locked.
+func (t *Tree[K, V]) lockLeftmostLeaf() *leafNode[K, V] {
+	n := t.lockRoot()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		child := parent.children[0]
+		child.lock()
+		parent.unlock()
+		n = child
+	}
+	return n.(*leafNode[K, V])
+}
+
+// lockRightmostLeaf descends from the root of the tree to its final leaf node,
+// holding the lock on each node only until the lock on its child has been
+// acquired. It returns the leaf node, which remains locked.
+func (t *Tree[K, V]) lockRightmostLeaf() *leafNode[K, V] {
+	n := t.lockRoot()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		child := parent.children[len(parent.children)-1]
+		child.lock()
+		parent.unlock()
+		n = child
+	}
+	return n.(*leafNode[K, V])
+}
+
+// Max returns the largest key in the tree and its associated value. When the
+// tree is empty it returns false.
+func (t *Tree[K, V]) Max() (K, V, bool) {
+	var key K
+	var value V
+	var ok bool
+
+	l := t.lockRightmostLeaf()
+
+	if index := len(l.runts) - 1; index >= 0 {
+		key = l.runts[index]
+		value = l.values[index]
+		ok = true
+	}
+
+	l.unlock()
+	return key, value, ok
+}
+
+// Min returns the smallest key in the tree and its associated value. When the
+// tree is empty it returns false.
+func (t *Tree[K, V]) Min() (K, V, bool) {
+	var key K
+	var value V
+	var ok bool
+
+	l := t.lockLeftmostLeaf()
+
+	if len(l.runts) > 0 {
+		key = l.runts[0]
+		value = l.values[0]
+		ok = true
+	}
+
+	l.unlock()
+	return key, value, ok
+}
+
+// Ceiling returns the smallest key in the tree that is greater than or equal to
+// key, and its associated value. When there is no such key it returns false.
+func (t *Tree[K, V]) Ceiling(key K) (K, V, bool) {
+	return t.after(key, true)
+}
+
+// Floor returns the largest key in the tree that is less than or equal to key,
+// and its associated value. When there is no such key it returns false.
+func (t *Tree[K, V]) Floor(key K) (K, V, bool) {
+	return t.before(key, true)
+}
+
+// Higher returns the smallest key in the tree that is greater than key, and
+// its associated value. When there is no such key it returns false.
+func (t *Tree[K, V]) Higher(key K) (K, V, bool) {
+	return t.after(key, false)
+}
+
+// Lower returns the largest key in the tree that is less than key, and its
+// associated value. When there is no such key it returns false.
+func (t *Tree[K, V]) Lower(key K) (K, V, bool) {
+	return t.before(key, false)
+}
+
+// after returns the first key-value pair whose key is greater than key, or
+// when inclusive is true, greater than or equal to key.
+func (t *Tree[K, V]) after(key K, inclusive bool) (K, V, bool) {
+	// The search for the next leaf might otherwise visit the tree both before
+	// and after a batch is applied.
+	l, index := t.lockLeafIn(t.currentEpoch(), func(t *Tree[K, V]) (*leafNode[K, V], int) {
+		return t.lockLeafAfter(key, inclusive)
+	})
+	if l == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	k, v := l.runts[index], l.values[index]
+	l.unlock()
+	return k, v, true
+}
+
+// lockLeafAfter returns the leaf node holding the first key that is greater
+// than key, or when inclusive is true, greater than or equal to key, along
+// with the index of that key in the leaf. The leaf node remains locked. When
+// there is no such key it returns a nil leaf node.
+//
+// Every key in the leaf after the one where key belongs is greater than key,
+// so when the leaf has no such key, the answer is the first key of the next
+// leaf. The leaves of a tree that has been cloned are not linked, so rather
+// than follow the link to the next leaf, this releases the leaf and searches
+// again, starting with the runt that routes keys to the node after the leaf.
+func (t *Tree[K, V]) lockLeafAfter(key K, inclusive bool) (*leafNode[K, V], int) {
+	var l *leafNode[K, V]
+	var index int
+
+	for {
+		var hi K
+		var bounded bool
+
+		n := t.lockRoot()
+		for n.isInternal() {
+			parent := n.(*internalNode[K, V])
+			i := searchLessThanOrEqualTo(key, parent.runts, t.compare)
+			if i < len(parent.runts)-1 {
+				hi, bounded = parent.runts[i+1], true
+			}
+			child := parent.children[i]
+			child.lock()
+			parent.unlock()
+			n = child
+		}
+		l = n.(*leafNode[K, V])
+
+		index = searchGreaterThanOrEqualTo(key, l.runts, t.compare)
+		if index < len(l.runts) {
+			if c := t.compare(l.runts[index], key); c < 0 || (c == 0 && !inclusive) {
+				index++
+			}
+		}
+		if index < len(l.runts) || t.cow.Load() == nil {
+			break
+		}
+		l.unlock()
+		if !bounded {
+			return nil, 0
+		}
+		key, inclusive = hi, true
+	}
+
+	for index == len(l.runts) {
+		if l.next == nil {
+			l.unlock()
+			return nil, 0
+		}
+		n := l.next
+		n.lock()
+		l.unlock()
+		l = n
+		index = 0
+	}
+
+	return l, index
+}
+
+// before returns the final key-value pair whose key is less than key, or when
+// inclusive is true, less than or equal to key.
+func (t *Tree[K, V]) before(key K, inclusive bool) (K, V, bool) {
+	l, index := t.lockLeafBefore(key, inclusive)
+	if l == nil {
+		var zeroK K
+		var zeroV V
+		return zeroK, zeroV, false
+	}
+	k, v := l.runts[index], l.values[index]
+	l.unlock()
+	return k, v, true
+}
+
+// lockLeafBefore returns the leaf node holding the final key that is less than
+// key, or when inclusive is true, less than or equal to key, along with the
+// index of that key in the leaf. The leaf node remains locked. When there is
+// no such key it returns a nil leaf node.
+//
+// The runt for a node is never greater than the smallest key under that node,
+// but may be smaller than it, so the leaf where key belongs might have no key
+// small enough, in which case the answer is the largest key of the previous
+// leaf. While descending, this holds the lock on the deepest node where the
+// descent did not take the first child, so it may descend from that node to
+// the largest key of the child before the one it took, without ever locking a
+// node before its parent.
+func (t *Tree[K, V]) lockLeafBefore(key K, inclusive bool) (*leafNode[K, V], int) {
+	var anchor *internalNode[K, V]
+	var anchorIndex int
+
+	n := t.lockRoot()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		index := searchLessThanOrEqualTo(key, parent.runts, t.compare)
+		child := parent.children[index]
+		child.lock()
+		if index > 0 {
+			if anchor != nil {
+				anchor.unlock()
+			}
+			anchor, anchorIndex = parent, index
+		} else {
+			parent.unlock()
+		}
+		n = child
+	}
+	l := n.(*leafNode[K, V])
+
+	// Count the keys in the leaf that are small enough.
+	index := searchGreaterThanOrEqualTo(key, l.runts, t.compare)
+	if index < len(l.runts) {
+		if c := t.compare(l.runts[index], key); c < 0 || (c == 0 && inclusive) {
+			index++
+		}
+	}
+
+	if index > 0 {
+		if anchor != nil {
+			anchor.unlock()
+		}
+		return l, index - 1
+	}
+	l.unlock()
+
+	if anchor == nil {
+		// The leaf is the first leaf of the tree.
+		return nil, 0
+	}
+
+	// Every key under the child before the one taken from anchor is smaller
+	// than key, so the answer is the largest key under that child.
+	n = anchor.children[anchorIndex-1]
+	n.lock()
+	anchor.unlock()
+	for n.isInternal() {
+		parent := n.(*internalNode[K, V])
+		child := parent.children[len(parent.children)-1]
+		child.lock()
+		parent.unlock()
+		n = child
+	}
+	l = n.(*leafNode[K, V])
+
+	if len(l.runts) == 0 {
+		l.unlock()
+		return nil, 0
+	}
+	return l, len(l.runts) - 1
+}
+
+// Search returns the value associated with key from the tree.
+func (t *Tree[K, V]) Search(key K) (V, bool) {
+	var value V
+	var ok bool
+
+	l := t.lockLeafForSearch(key)
+
+	if len(l.runts) > 0 {
+		i := searchGreaterThanOrEqualTo(key, l.runts, t.compare)
+		if t.compare(key, l.runts[i]) == 0 {
+			value = l.values[i]
+			ok = true
+		}
+	}
+
+	l.unlock()
+	return value, ok
+}
+
+// Update searches for key and invokes callback with key's associated value,
+// waits for callback to return a new value, and stores callback's return value
+// as the new value for key. When key is not found, callback will be invoked
+// with the zero value of V and false to signify the key was not found. After
+// this method returns, the key will exist in the tree with the new value
+// returned by the callback function.
+func (t *Tree[K, V]) Update(key K, callback func(V, bool) V) {
+	t.beginWrite()
+	defer t.endWrite()
+
+	ln, ancestors := t.lockLeafForInsert(key)
+
+	// When the new value will become the first element in a leaf, which is only
+	// possible for an empty tree, or when new key comes after final leaf runt,
+	// a simple append will suffice.
+	if len(ln.runts) == 0 || t.compare(key, ln.runts[len(ln.runts)-1]) > 0 {
+		var zero V
+		value := callback(zero, false)
+		ln.runts = append(ln.runts, key)
+		ln.values = append(ln.values, value)
+		t.length.Add(1)
+		t.unlockPath(ln, ancestors, 1)
+		return
+	}
+
+	index := searchGreaterThanOrEqualTo(key, ln.runts, t.compare)
+
+	if t.compare(key, ln.runts[index]) == 0 {
+		// When the key matches the runt, merely need to update the value.
+		ln.values[index] = callback(ln.values[index], true)
+		t.unlockPath(ln, ancestors, 0)
+		return
+	}
+
+	var zero V
+	ln.insertAt(index, key, callback(zero, false))
+	t.length.Add(1)
+	t.unlockPath(ln, ancestors, 1)
+}
+
+// Len returns the number of key-value pairs in the tree. The count is
+// maintained as pairs are inserted and deleted, so it does not require
+// visiting any of the tree's nodes.
+func (t *Tree[K, V]) Len() int {
+	return int(t.length.Load())
+}
+
+// Clear removes every key-value pair from the tree. It waits for every
+// operation and cursor that holds the lock on any node of the tree to release
+// it, by acquiring the lock on every node in the same order that other
+// operations acquire them, and then replaces the root with an empty leaf node.
+// Operations that start after Clear returns observe an empty tree, and an
+// operation blocked on the former root starts over from the new root.
+//
+// Like DeleteRange, a cursor that is neither exhausted nor closed prevents
+// Clear from completing.
+func (t *Tree[K, V]) Clear() {
+	t.beginWrite()
+	defer t.endWrite()
+
+	root := t.lockRoot()
+	lockSubtree(root)
+
+	t.setRoot(&leafNode[K, V]{
+		runts:  make([]K, 0, t.order),
+		values: make([]V, 0, t.order),
+		cow:    t.cow.Load(),
+	})
+	t.length.Store(0)
+
+	unlockSubtree(root)
+	root.unlock()
+}
+
+// lockSubtree acquires the lock on every node below the locked node n, in
+// depth-first order, so leaves are locked in ascending order.
+func lockSubtree[K any, V any](n node[K, V]) {
+	if i, ok := n.(*internalNode[K, V]); ok {
+		for _, child := range i.children {
+			child.lock()
+			lockSubtree(child)
+		}
+	}
+}
+
+// unlockSubtree releases the lock on every node below n.
+func unlockSubtree[K any, V any](n node[K, V]) {
+	if i, ok := n.(*internalNode[K, V]); ok {
+		for _, child := range i.children {
+			unlockSubtree(child)
+			child.unlock()
+		}
+	}
+}
+
+// NewScanner returns a cursor that iteratively returns key-value pairs from the
+// tree in ascending order starting at key, or if key is not found the next key,
+// and ending after all successive pairs have been returned. To enumerate all
+// values in a Tree, invoke with key set to the smallest value of K.
+//
+// NOTE: This function exists still holding the lock on one of the tree's leaf
+// nodes, which may block other operations on the tree that require modification
+// of the locked node. The leaf node is only unlocked either by closing the
+// Cursor, or after all key-value pairs have been visited using Scan.
+func (t *Tree[K, V]) NewScanner(key K) *Cursor[K, V] {
+	return newCursor(t, func(t *Tree[K, V]) (*leafNode[K, V], int) {
+		return t.lockLeafAfter(key, true)
+	})
+}
+
+// NewReverseScanner returns a cursor that iteratively returns key-value pairs
+// from the tree in descending order starting at key, or if key is not found
+// the previous key, and ending after all preceding pairs have been returned.
+// To enumerate all values in a Tree in descending order, invoke with key set
+// to the largest value of K.
+//
+// Like NewScanner, this function exits still holding the lock on one of the
+// tree's leaf nodes. An operation holding the lock on a leaf only ever waits for
+// the lock on a leaf after it, as a cursor scanning in ascending order does, so
+// rather than wait for the lock on the previous leaf while holding the lock on
+// the current one, which could deadlock with such an operation, the cursor
+// releases the current leaf and searches the tree again from the root whenever
+// the previous leaf is busy.
+func (t *Tree[K, V]) NewReverseScanner(key K) *Cursor[K, V] {
+	t.cursors.Add(1)
+	c := &Cursor[K, V]{t: t, reverse: true, epoch: t.currentEpoch()}
+	if c.l, c.i = c.lockLeaf(func(t *Tree[K, V]) (*leafNode[K, V], int) {
+		return t.lockLeafBefore(key, true)
+	}); c.l == nil {
+		c.finish()
+	}
+	c.i++
+	return c
+}
+
+// NewBufferedScanner returns a cursor that iteratively returns key-value pairs
+// from the tree in ascending order starting at key, or if key is not found the
+// next key, and ending after all successive pairs have been returned.
+//
+// Unlike the cursor returned by NewScanner, this cursor does not hold the lock
+// on any leaf node between calls to Scan. It copies the remaining key-value
+// pairs of a leaf node into its buffer, releases the lock, and when the buffer
+// is exhausted, searches the tree again for the key after the final key it
+// returned. Each buffer is a consistent copy of one leaf node, and every pair
+// inserted after the final key returned before the buffer is refilled will be
+// returned, but a pair deleted from the tree after being copied is still
+// returned. Unlike the other cursors, it searches the tree as it is when the
+// buffer is refilled, so it returns the pairs of a batch applied after it was
+// created, and may return some of them but not others. Abandoning the cursor
+// without calling Close does not block other operations on the tree.
+func (t *Tree[K, V]) NewBufferedScanner(key K) *Cursor[K, V] {
+	return &Cursor[K, V]{t: t, i: -1, buffered: true, last: key, inclusive: true}
+}
+
+// RangeOptions specifies which bounds of the range enumerated by a cursor from
+// NewRangeScanner are excluded. The zero value includes both bounds.
+type RangeOptions struct {
+	ExcludeLo bool // when true the cursor does not return lo
+	ExcludeHi bool // when true the cursor does not return hi
+}
+
+// NewRangeScanner returns a cursor that iteratively returns key-value pairs
+// from the tree in ascending order, starting at lo and ending at hi, each of
+// which is included unless excluded by opts. If lo is not found the cursor
+// starts with the next key.
+//
+// Like NewScanner, this function exits still holding the lock on one of the
+// tree's leaf nodes. However, the cursor releases the lock as soon as Scan
+// encounters a key past hi, so it is not necessary to call Close when Scan is
+// called repeatedly until it returns false.
+func (t *Tree[K, V]) NewRangeScanner(lo, hi K, opts RangeOptions) *Cursor[K, V] {
+	c := newCursor(t, func(t *Tree[K, V]) (*leafNode[K, V], int) {
+		return t.lockLeafAfter(lo, !opts.ExcludeLo)
+	})
+	c.hi = hi
+	c.bounded = true
+	c.excludeHi = opts.ExcludeHi
+	return c
+}
+
+// Cursor is used to enumerate key-value pairs from the tree in ascending or
+// descending order.
+type Cursor[K any, V any] struct {
+	t         *Tree[K, V]  // compares keys with hi, and searches again for the next leaf when leaves are not linked
+	epoch     *epoch[K, V] // the epoch of the tree when the cursor was created, or nil when buffered
+	l         *leafNode[K, V]
+	i         int
+	reverse   bool
+	bounded   bool // when true the cursor stops after hi
+	excludeHi bool
+	hi        K
+
+	// A buffered cursor holds copies of key-value pairs rather than a locked
+	// leaf node.
+	buffered  bool
+	inclusive bool // when true the next search may return last
+	done      bool // when true the cursor has been closed or exhausted
+	last      K    // the final key returned, or the key where scanning starts
+	keys      []K
+	values    []V
+}
+
+// newCursor returns a cursor that starts at the key-value pair found by lock,
+// which locks a leaf node of the tree, and returns it along with the index of
+// the pair.
+func newCursor[K any, V any](t *Tree[K, V], lock func(*Tree[K, V]) (*leafNode[K, V], int)) *Cursor[K, V] {
+	// The cursor is counted before it reads the epoch, so the leaves of the
+	// tree are not linked again while it might search the tree as it was
+	// before a batch, whose leaves the tree shares.
+	t.cursors.Add(1)
+	c := &Cursor[K, V]{t: t, epoch: t.currentEpoch()}
+	if c.l, c.i = c.lockLeaf(lock); c.l == nil {
+		c.finish()
+	}
+	// Initialize cursor with index one smaller than requested, so initial scan
+	// lines up the cursor to reference the desired key-value pair.
+	c.i--
+	return c
+}
+
+// lockLeaf invokes lock with the tree as it was in the cursor's epoch, and
+// returns the leaf node and index it returns. See Tree.lockLeafIn.
+func (c *Cursor[K, V]) lockLeaf(lock func(*Tree[K, V]) (*leafNode[K, V], int)) (*leafNode[K, V], int) {
+	return c.t.lockLeafIn(c.epoch, lock)
+}
+
+// finish releases the lock on the leaf node under the cursor, if any, once the
+// cursor is closed or exhausted. Once no cursor might hold a leaf node, the
+// leaves of the tree may be linked again. See Tree.relink.
+func (c *Cursor[K, V]) finish() {
+	if c.l != nil {
+		c.l.unlock()
+		c.l = nil
+	}
+	if c.t.cursors.Add(-1) == 0 {
+		c.t.maybeRelink()
+	}
+}
+
+// Close releases the lock on the leaf node under the cursor. This method is
+// provided to signal no further intention of scanning the remainder key-value
+// pairs in the tree. It is not necessary to call Close if Scan is called
+// repeatedly until Scan returns false.
+func (c *Cursor[K, V]) Close() error {
+	if c.buffered {
+		c.done = true
+		c.keys, c.values = nil, nil
+		c.i = -1
+		return nil
+	}
+	if c.l != nil {
+		c.finish()
+	}
+	return nil
+}
+
+// Pair returns the key-value pair referenced by the cursor.
+func (c *Cursor[K, V]) Pair() (K, V) {
+	if c.buffered {
+		return c.keys[c.i], c.values[c.i]
+	}
+	return c.l.runts[c.i], c.l.values[c.i]
+}
+
+// Scan advances the cursor to reference the next key-value pair in the tree in
+// the cursor's order, and returns true when there is at least one more
+// key-value pair to be observed with the Pair method. If the final key-value
+// pair has already been observed, this unlocks the final leaf visited and
+// returns false.
+func (c *Cursor[K, V]) Scan() bool {
+	if c.buffered {
+		return c.scanBuffered()
+	}
+	if c.l == nil {
+		// The cursor has already been closed or exhausted.
+		return false
+	}
+	if c.reverse {
+		return c.scanReverse()
+	}
+	if c.i++; c.i == len(c.l.runts) {
+		if c.epoch.tree(c.t).cow.Load() != nil {
+			// The leaves of a tree that has been cloned are not linked, so
+			// release this leaf and search for the key after its final key,
+			// which is the final key returned by the cursor.
+			if c.i == 0 {
+				c.finish()
+				return false
+			}
+			key := c.l.runts[c.i-1]
+			c.l.unlock()
+			if c.l, c.i = c.lockLeaf(func(t *Tree[K, V]) (*leafNode[K, V], int) {
+				return t.lockLeafAfter(key, false)
+			}); c.l == nil {
+				c.finish()
+				return false
+			}
+		} else {
+			// Like lockLeafAfter, pass over any leaf that holds no keys.
+			for c.i == len(c.l.runts) {
+				n := c.l.next
+				if n == nil {
+					c.finish()
+					return false
+				}
+				n.lock()
+				c.l.unlock()
+				c.l = n
+				c.i = 0
+			}
+		}
+	}
+	if c.bounded {
+		if diff := c.t.compare(c.l.runts[c.i], c.hi); diff > 0 || (diff == 0 && c.excludeHi) {
+			c.finish()
+			return false
+		}
+	}
+	return true
+}
+
+// scanReverse advances the cursor to reference the previous key-value pair in
+// the tree.
+func (c *Cursor[K, V]) scanReverse() bool {
+	for c.i--; c.i < 0; {
+		if c.epoch.tree(c.t).cow.Load() == nil {
+			p := c.l.prev.Load()
+			if p == nil {
+				c.finish()
+				return false
+			}
+			if p.mutex.TryLock() {
+				if p.next == c.l {
+					// The next pointer of a leaf is only modified while
+					// holding its lock, so p is still the leaf before this
+					// one.
+					c.l.unlock()
+					c.l = p
+					c.i = len(p.runts) - 1
+					continue
+				}
+				// The previous leaf was split or merged since this cursor
+				// read its prev pointer.
+				p.unlock()
+			}
+		}
+		// Another operation holds the lock on the previous leaf, and might be
+		// waiting for the lock on this leaf, or the leaves of the tree are not
+		// linked because it has been cloned, so release this leaf, and search
+		// for the key before the final key returned by the cursor, which is
+		// the first key in this leaf.
+		key := c.l.runts[0]
+		c.l.unlock()
+		if c.l, c.i = c.lockLeaf(func(t *Tree[K, V]) (*leafNode[K, V], int) {
+			return t.lockLeafBefore(key, false)
+		}); c.l == nil {
+			c.finish()
+			return false
+		}
+	}
+	return true
+}
+
+// scanBuffered advances the cursor to reference the next key-value pair in its
+// buffer, refilling the buffer from the tree when it is exhausted.
+func (c *Cursor[K, V]) scanBuffered() bool {
+	if c.i++; c.i < len(c.keys) {
+		c.last = c.keys[c.i]
+		c.inclusive = false
+		return true
+	}
+	if c.done {
+		c.keys, c.values = nil, nil
+		return false
+	}
+
+	l, index := c.t.lockLeafAfter(c.last, c.inclusive)
+	if l == nil {
+		c.done = true
+		c.keys, c.values = nil, nil
+		return false
+	}
+	c.keys = append(c.keys[:0], l.runts[index:]...)
+	c.values = append(c.values[:0], l.values[index:]...)
+	l.unlock()
+
+	c.i = 0
+	c.last = c.keys[0]
+	c.inclusive = false
+	return true
+}
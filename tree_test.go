@@ -0,0 +1,2319 @@
+package gobptree
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// testKeys converts the int values used by the test cases into keys of type K,
+// preserving their relative order. This allows a single test to cover every
+// key type for which the library provides a named tree type.
+type testKeys[K cmp.Ordered] func(int) K
+
+// slice returns a slice of keys from its arguments.
+func (k testKeys[K]) slice(items ...int) []K {
+	keys := make([]K, len(items))
+	for i := 0; i < len(items); i++ {
+		keys[i] = k(items[i])
+	}
+	return keys
+}
+
+// leafFrom returns a leaf node with the specified next sibling, holding the
+// keys from its arguments, where each key is also used as its value.
+func (k testKeys[K]) leafFrom(next *leafNode[K, interface{}], items ...int) *leafNode[K, interface{}] {
+	n := &leafNode[K, interface{}]{
+		runts:  make([]K, len(items)),
+		values: make([]interface{}, len(items)),
+		next:   next,
+	}
+	for i := 0; i < len(items); i++ {
+		n.runts[i] = k(items[i])
+		n.values[i] = k(items[i])
+	}
+	return n
+}
+
+func internalFrom[K cmp.Ordered](items ...node[K, interface{}]) *internalNode[K, interface{}] {
+	n := &internalNode[K, interface{}]{
+		runts:    make([]K, len(items)),
+		children: make([]node[K, interface{}], len(items)),
+	}
+	for i := 0; i < len(items); i++ {
+		n.runts[i] = items[i].smallest()
+		n.children[i] = items[i]
+	}
+	return n
+}
+
+// forEachKeyType runs the provided instantiations of a generic test function
+// as sub-tests, one for each key type for which the library provides a named
+// tree type.
+func forEachKeyType(t *testing.T,
+	i32 func(*testing.T, testKeys[int32]),
+	i64 func(*testing.T, testKeys[int64]),
+	u32 func(*testing.T, testKeys[uint32]),
+	u64 func(*testing.T, testKeys[uint64]),
+	str func(*testing.T, testKeys[string]),
+) {
+	t.Helper()
+	t.Run("int32", func(t *testing.T) { i32(t, func(i int) int32 { return int32(i) }) })
+	t.Run("int64", func(t *testing.T) { i64(t, func(i int) int64 { return int64(i) }) })
+	t.Run("uint32", func(t *testing.T) { u32(t, func(i int) uint32 { return uint32(i) }) })
+	t.Run("uint64", func(t *testing.T) { u64(t, func(i int) uint64 { return uint64(i) }) })
+	t.Run("string", func(t *testing.T) { str(t, func(i int) string { return fmt.Sprintf("%07d", i) }) })
+}
+
+////////////////////////////////////////
+
+func ensureLeaf[K cmp.Ordered](t *testing.T, actual, expected *leafNode[K, interface{}]) {
+	t.Helper()
+
+	if got, want := len(actual.runts), len(expected.runts); got != want {
+		t.Errorf("length(runts) GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := len(actual.values), len(expected.values); got != want {
+		t.Errorf("length(values) GOT: %v; WANT: %v", got, want)
+	}
+	for i := 0; i < len(actual.runts) && i < len(expected.runts); i++ {
+		if got, want := actual.runts[i], expected.runts[i]; got != want {
+			t.Errorf("runts[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+		if got, want := actual.values[i], expected.values[i]; got != want {
+			t.Errorf("values[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+	}
+	if got, want := actual.next, expected.next; got != want {
+		t.Errorf("next GOT: %v; WANT: %v", got, want)
+	}
+	if t.Failed() {
+		t.Errorf("\nGOT:\n\t%#v\nWANT:\n\t%#v", actual, expected)
+	}
+}
+
+func ensureInternal[K cmp.Ordered](t *testing.T, a, e *internalNode[K, interface{}]) {
+	t.Helper()
+
+	if got, want := len(a.runts), len(e.runts); got != want {
+		t.Errorf("length(runts) GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := len(a.children), len(e.children); got != want {
+		t.Errorf("length(children) GOT: %v; WANT: %v", got, want)
+	}
+	for i := 0; i < len(a.runts) && i < len(e.runts); i++ {
+		if got, want := a.runts[i], e.runts[i]; got != want {
+			t.Errorf("runts[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+		ensureNode(t, a.children[i], e.children[i])
+	}
+}
+
+func ensureNode[K cmp.Ordered](t *testing.T, actual, expected node[K, interface{}]) {
+	t.Helper()
+
+	switch e := expected.(type) {
+	case *leafNode[K, interface{}]:
+		a, ok := actual.(*leafNode[K, interface{}])
+		if got, want := ok, true; got != want {
+			t.Errorf("GOT: %T; WANT: %T", actual, e)
+		}
+		ensureLeaf(t, a, e)
+	case *internalNode[K, interface{}]:
+		a, ok := actual.(*internalNode[K, interface{}])
+		if got, want := ok, true; got != want {
+			t.Errorf("GOT: %T; WANT: %T", actual, e)
+		}
+		ensureInternal(t, a, e)
+	default:
+		t.Errorf("GOT: %T; WANT: node", expected)
+	}
+}
+
+// ensureScan scans the tree starting at the specified key and ensures the
+// cursor enumerates exactly the expected keys.
+func ensureScan[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}], key K, expected []K) {
+	t.Helper()
+
+	var keys []K
+	c := d.NewScanner(key)
+	for c.Scan() {
+		k, _ := c.Pair()
+		keys = append(keys, k)
+	}
+
+	if got, want := len(keys), len(expected); got != want {
+		t.Errorf("length(keys) GOT: %v; WANT: %v", got, want)
+	}
+	for i := 0; i < len(keys) && i < len(expected); i++ {
+		if got, want := keys[i], expected[i]; got != want {
+			t.Errorf("keys[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+	}
+}
+
+// ensureReverseScan scans the tree in descending order starting at the
+// specified key and ensures the cursor enumerates exactly the expected keys.
+func ensureReverseScan[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}], key K, expected []K) {
+	t.Helper()
+
+	var keys []K
+	c := d.NewReverseScanner(key)
+	for c.Scan() {
+		k, _ := c.Pair()
+		keys = append(keys, k)
+	}
+
+	if got, want := len(keys), len(expected); got != want {
+		t.Errorf("length(keys) GOT: %v; WANT: %v", got, want)
+	}
+	for i := 0; i < len(keys) && i < len(expected); i++ {
+		if got, want := keys[i], expected[i]; got != want {
+			t.Errorf("keys[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+	}
+}
+
+// ensureLeafLinks ensures the prev pointer of every leaf in the tree refers to
+// the leaf whose next pointer refers to it.
+func ensureLeafLinks[K cmp.Ordered](t *testing.T, d *Tree[K, interface{}]) {
+	t.Helper()
+
+	l := d.lockLeftmostLeaf()
+	l.unlock()
+
+	if got := l.prev.Load(); got != nil {
+		t.Errorf("GOT: %v; WANT: %v", got, nil)
+	}
+	for ; l.next != nil; l = l.next {
+		if got, want := l.next.prev.Load(), l; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+}
+
+////////////////////////////////////////
+
+func TestBinarySearch(t *testing.T) {
+	forEachKeyType(t, testBinarySearch[int32], testBinarySearch[int64], testBinarySearch[uint32], testBinarySearch[uint64], testBinarySearch[string])
+}
+
+func testBinarySearch[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("skip values", func(t *testing.T) {
+		values := k.slice(1, 3, 5, 7, 9, 11, 13)
+
+		cases := []struct{ key, want int }{
+			{0, 0}, {1, 0}, {2, 1}, {3, 1}, {4, 2}, {5, 2}, {6, 3}, {7, 3},
+			{8, 4}, {9, 4}, {10, 5}, {11, 5}, {12, 6}, {13, 6}, {14, 6},
+		}
+
+		for _, c := range cases {
+			if got, want := searchGreaterThanOrEqualTo(k(c.key), values, cmp.Compare[K]), c.want; got != want {
+				t.Errorf("KEY: %v; GOT: %v; WANT: %v", c.key, got, want)
+			}
+		}
+	})
+	t.Run("greater than or equal to", func(t *testing.T) {
+		t.Run("empty list", func(t *testing.T) {
+			i := searchGreaterThanOrEqualTo(k(1), nil, cmp.Compare[K])
+			if got, want := i, 0; got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+		})
+		t.Run("single item list", func(t *testing.T) {
+			t.Run("key before", func(t *testing.T) {
+				i := searchGreaterThanOrEqualTo(k(1), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("key match", func(t *testing.T) {
+				i := searchGreaterThanOrEqualTo(k(2), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("key after", func(t *testing.T) {
+				i := searchGreaterThanOrEqualTo(k(3), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+		})
+		t.Run("multiple item list", func(t *testing.T) {
+			cases := []struct {
+				name      string
+				key, want int
+			}{
+				{"key before first", 1, 0},
+				{"key match first", 2, 0},
+				{"key between first and second", 3, 1},
+				{"key match second", 4, 1},
+				{"key between second and third", 5, 2},
+				{"key match third", 6, 2},
+				{"key after third", 7, 2},
+			}
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					i := searchGreaterThanOrEqualTo(k(c.key), k.slice(2, 4, 6), cmp.Compare[K])
+					if got, want := i, c.want; got != want {
+						t.Fatalf("GOT: %v; WANT: %v", got, want)
+					}
+				})
+			}
+		})
+	})
+	t.Run("less than or equal to", func(t *testing.T) {
+		t.Run("empty list", func(t *testing.T) {
+			i := searchLessThanOrEqualTo(k(1), nil, cmp.Compare[K])
+			if got, want := i, 0; got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+		})
+		t.Run("single item list", func(t *testing.T) {
+			t.Run("key before", func(t *testing.T) {
+				i := searchLessThanOrEqualTo(k(1), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("key match", func(t *testing.T) {
+				i := searchLessThanOrEqualTo(k(2), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("key after", func(t *testing.T) {
+				i := searchLessThanOrEqualTo(k(3), k.slice(2), cmp.Compare[K])
+				if got, want := i, 0; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+		})
+		t.Run("multiple item list", func(t *testing.T) {
+			cases := []struct {
+				name      string
+				key, want int
+			}{
+				{"key before first", 1, 0},
+				{"key match first", 2, 0},
+				{"key between first and second", 3, 0},
+				{"key match second", 4, 1},
+				{"key between second and third", 5, 1},
+				{"key match third", 6, 2},
+				{"key after third", 7, 2},
+			}
+			for _, c := range cases {
+				t.Run(c.name, func(t *testing.T) {
+					i := searchLessThanOrEqualTo(k(c.key), k.slice(2, 4, 6), cmp.Compare[K])
+					if got, want := i, c.want; got != want {
+						t.Fatalf("GOT: %v; WANT: %v", got, want)
+					}
+				})
+			}
+		})
+	})
+}
+
+func TestNewTreeReturnsErrorWhenInvalidOrder(t *testing.T) {
+	for _, v := range []int{0, -1, 1, 3, 11} {
+		_, err := NewTree[int64, interface{}](v)
+		ensureError(t, err, fmt.Sprintf("not a power of 2: %d", v))
+	}
+}
+
+func TestNamedTreeConstructors(t *testing.T) {
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := NewInt32Tree(3)
+		ensureError(t, err, "not a power of 2: 3")
+		_, err = NewInt64Tree(3)
+		ensureError(t, err, "not a power of 2: 3")
+		_, err = NewUint32Tree(3)
+		ensureError(t, err, "not a power of 2: 3")
+		_, err = NewUint64Tree(3)
+		ensureError(t, err, "not a power of 2: 3")
+		_, err = NewStringTree(3)
+		ensureError(t, err, "not a power of 2: 3")
+	})
+	t.Run("valid order", func(t *testing.T) {
+		d, err := NewStringTree(4)
+		ensureError(t, err)
+		d.Insert("one", 1)
+		value, ok := d.Search("one")
+		if got, want := ok, true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, 1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestNewTreeFunc(t *testing.T) {
+	t.Run("invalid order", func(t *testing.T) {
+		_, err := NewTreeFunc[int, int](3, cmp.Compare[int])
+		ensureError(t, err, "not a power of 2: 3")
+	})
+	t.Run("missing comparison function", func(t *testing.T) {
+		_, err := NewTreeFunc[int, int](4, nil)
+		ensureError(t, err, "without a comparison function")
+	})
+	t.Run("descending order", func(t *testing.T) {
+		d, err := NewTreeFunc[int, int](4, func(a, b int) int { return cmp.Compare(b, a) })
+		ensureError(t, err)
+		for _, v := range randomizedValues[:100] {
+			d.Insert(v, -v)
+		}
+
+		var previous int
+		var count int
+		c := d.NewScanner(randomizedValues[0])
+		for c.Scan() {
+			k, v := c.Pair()
+			if count > 0 && k >= previous {
+				t.Errorf("GOT: %v; WANT: < %v", k, previous)
+			}
+			if got, want := v, -k; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			previous = k
+			count++
+		}
+		if count == 0 {
+			t.Errorf("GOT: %v; WANT: > %v", count, 0)
+		}
+	})
+	t.Run("case-insensitive order", func(t *testing.T) {
+		d, err := NewTreeFunc[string, int](4, func(a, b string) int {
+			return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+		})
+		ensureError(t, err)
+		d.Insert("Alpha", 1)
+		d.Insert("bravo", 2)
+		d.Insert("ALPHA", 3)
+
+		value, ok := d.Search("alpha")
+		if got, want := ok, true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, 3; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		d.Delete("BRAVO")
+		if _, ok := d.Search("bravo"); ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, false)
+		}
+	})
+	t.Run("struct keys", func(t *testing.T) {
+		type point struct{ x, y int }
+
+		d, err := NewTreeFunc[point, string](4, func(a, b point) int {
+			if c := cmp.Compare(a.x, b.x); c != 0 {
+				return c
+			}
+			return cmp.Compare(a.y, b.y)
+		})
+		ensureError(t, err)
+		for x := 0; x < 5; x++ {
+			for y := 4; y >= 0; y-- {
+				d.Insert(point{x, y}, fmt.Sprintf("%d,%d", x, y))
+			}
+		}
+
+		var values []string
+		c := d.NewScanner(point{3, 3})
+		for c.Scan() {
+			_, v := c.Pair()
+			values = append(values, v)
+		}
+		expected := []string{"3,3", "3,4", "4,0", "4,1", "4,2", "4,3", "4,4"}
+		if got, want := len(values), len(expected); got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+		for i := range expected {
+			if got, want := values[i], expected[i]; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		}
+	})
+}
+
+func TestInternalNodeMaybeSplit(t *testing.T) {
+	forEachKeyType(t, testInternalNodeMaybeSplit[int32], testInternalNodeMaybeSplit[int64], testInternalNodeMaybeSplit[uint32], testInternalNodeMaybeSplit[uint64], testInternalNodeMaybeSplit[string])
+}
+
+func testInternalNodeMaybeSplit[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	leafE := k.leafFrom(nil, 50, 51, 52, 53)
+	leafD := k.leafFrom(leafE, 40, 41, 42, 43)
+	leafC := k.leafFrom(leafD, 30, 31, 32, 33)
+	leafB := k.leafFrom(leafC, 20, 21, 22, 23)
+	leafA := k.leafFrom(leafB, 10, 11, 12, 13)
+
+	t.Run("does nothing when not full", func(t *testing.T) {
+		ni := internalFrom[K](leafA, leafB, leafC, leafD)
+		_, right := ni.maybeSplit(6)
+		if right != nil {
+			t.Errorf("GOT: %v; WANT: %v", right, nil)
+		}
+	})
+
+	t.Run("splits when full", func(t *testing.T) {
+		ni := internalFrom[K](leafA, leafB, leafC, leafD)
+		expectedLeft := internalFrom[K](leafA, leafB)
+		expectedRight := internalFrom[K](leafC, leafD)
+
+		leftNode, rightNode := ni.maybeSplit(4)
+
+		ensureNode(t, leftNode, node[K, interface{}](expectedLeft))
+		ensureNode(t, rightNode, node[K, interface{}](expectedRight))
+	})
+
+	t.Run("splits when more than full", func(t *testing.T) {
+		ni := internalFrom[K](leafA, leafB, leafC, leafD, leafE)
+		expectedLeft := internalFrom[K](leafA, leafB)
+		expectedRight := internalFrom[K](leafC, leafD, leafE)
+
+		leftNode, rightNode := ni.maybeSplit(4)
+
+		ensureNode(t, leftNode, node[K, interface{}](expectedLeft))
+		ensureNode(t, rightNode, node[K, interface{}](expectedRight))
+	})
+}
+
+func TestInternalNodeInsertSmallerKey(t *testing.T) {
+	forEachKeyType(t, testInternalNodeInsertSmallerKey[int32], testInternalNodeInsertSmallerKey[int64], testInternalNodeInsertSmallerKey[uint32], testInternalNodeInsertSmallerKey[uint64], testInternalNodeInsertSmallerKey[string])
+}
+
+func testInternalNodeInsertSmallerKey[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	leafB := k.leafFrom(nil, 21, 22)
+	leafA := k.leafFrom(leafB, 12, 13)
+	ni := internalFrom[K](leafA, leafB)
+
+	d := &Tree[K, interface{}]{root: ni, compare: cmp.Compare[K], order: 4}
+
+	d.Insert(k(11), k(11))
+
+	if got, want := ni.runts[0], k(11); got != want {
+		t.Fatalf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestLeafNodeMaybeSplit(t *testing.T) {
+	forEachKeyType(t, testLeafNodeMaybeSplit[int32], testLeafNodeMaybeSplit[int64], testLeafNodeMaybeSplit[uint32], testLeafNodeMaybeSplit[uint64], testLeafNodeMaybeSplit[string])
+}
+
+func testLeafNodeMaybeSplit[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	gimme := func() (*leafNode[K, interface{}], *leafNode[K, interface{}]) {
+		leafB := k.leafFrom(nil, 21, 22, 23, 24)
+		leafA := k.leafFrom(leafB, 11, 12, 13, 14)
+		return leafA, leafB
+	}
+
+	t.Run("when not full does nothing", func(t *testing.T) {
+		_, leafB := gimme()
+		_, right := leafB.maybeSplit(6)
+		if right != nil {
+			t.Errorf("GOT: %v; WANT: %v", right, nil)
+		}
+	})
+	t.Run("splits non-right edge when full", func(t *testing.T) {
+		leafA, leafB := gimme()
+		leftNode, rightNode := leafA.maybeSplit(4)
+		ensureNode(t, leftNode, node[K, interface{}](k.leafFrom(rightNode.(*leafNode[K, interface{}]), 11, 12)))
+		ensureNode(t, rightNode, node[K, interface{}](k.leafFrom(leafB, 13, 14)))
+		if got, want := rightNode.(*leafNode[K, interface{}]).prev.Load(), leafA; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := node[K, interface{}](leafB.prev.Load()), rightNode; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("splits right edge when full", func(t *testing.T) {
+		leafA, leafB := gimme()
+		leftNode, rightNode := leafB.maybeSplit(4)
+		if got, want := node[K, interface{}](leafA.next), leftNode; got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureNode(t, leftNode, node[K, interface{}](k.leafFrom(rightNode.(*leafNode[K, interface{}]), 21, 22)))
+		ensureNode(t, rightNode, node[K, interface{}](k.leafFrom(nil, 23, 24)))
+	})
+	t.Run("splits when more than full", func(t *testing.T) {
+		leaf := k.leafFrom(nil, 11, 12, 13, 14, 15, 16)
+		leftNode, rightNode := leaf.maybeSplit(4)
+		ensureNode(t, leftNode, node[K, interface{}](k.leafFrom(rightNode.(*leafNode[K, interface{}]), 11, 12)))
+		ensureNode(t, rightNode, node[K, interface{}](k.leafFrom(nil, 13, 14, 15, 16)))
+	})
+}
+
+func TestInsertIntoSingleLeafTree(t *testing.T) {
+	forEachKeyType(t, testInsertIntoSingleLeafTree[int32], testInsertIntoSingleLeafTree[int64], testInsertIntoSingleLeafTree[uint32], testInsertIntoSingleLeafTree[uint64], testInsertIntoSingleLeafTree[string])
+}
+
+func testInsertIntoSingleLeafTree[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("when fewer than order elements", func(t *testing.T) {
+		t.Run("when empty", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](4)
+			nl, ok := d.root.(*leafNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, false)
+			}
+			d.Insert(k(30), k(30))
+			ensureLeaf(t, nl, k.leafFrom(nil, 30))
+		})
+		t.Run("when less than first runt", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](4)
+			nl, ok := d.root.(*leafNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, false)
+			}
+			d.Insert(k(30), k(30))
+			d.Insert(k(10), k(10))
+			ensureLeaf(t, nl, k.leafFrom(nil, 10, 30))
+		})
+		t.Run("when update value", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](4)
+			nl, ok := d.root.(*leafNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, false)
+			}
+			d.Insert(k(30), k(30))
+			d.Insert(k(10), k(10))
+			d.Insert(k(30), k(333))
+			ensureLeaf(t, nl, &leafNode[K, interface{}]{
+				runts:  k.slice(10, 30),
+				values: []interface{}{k(10), k(333)},
+			})
+		})
+		t.Run("when between first and final runt", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](4)
+			nl, ok := d.root.(*leafNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, false)
+			}
+			d.Insert(k(30), k(30))
+			d.Insert(k(10), k(10))
+			d.Insert(k(20), k(20))
+			ensureLeaf(t, nl, k.leafFrom(nil, 10, 20, 30))
+		})
+		t.Run("when after final runt", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](4)
+			nl, ok := d.root.(*leafNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, false)
+			}
+			d.Insert(k(30), k(30))
+			d.Insert(k(10), k(10))
+			d.Insert(k(20), k(20))
+			d.Insert(k(40), k(40))
+			ensureLeaf(t, nl, k.leafFrom(nil, 10, 20, 30, 40))
+		})
+	})
+
+	t.Run("when insertion splits single leaf node", func(t *testing.T) {
+		gimme := func() *Tree[K, interface{}] {
+			d, _ := NewTree[K, interface{}](4)
+			for _, v := range []int{10, 20, 30, 40} {
+				d.Insert(k(v), k(v))
+			}
+			return d
+		}
+		ensureRoot := func(t *testing.T, d *Tree[K, interface{}], leftRunt int, left []int, rightRunt int, right []int) {
+			t.Helper()
+			root, ok := d.root.(*internalNode[K, interface{}])
+			if !ok {
+				t.Fatalf("GOT: %v; WANT: %v", ok, true)
+			}
+			// root should have two runts and two leaf nodes for children
+			if got, want := len(root.runts), 2; got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+			if got, want := len(root.children), 2; got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+			// ensure children nodes are as expected for this case
+			if got, want := root.runts[0], k(leftRunt); got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+			ensureNode(t, root.children[0], node[K, interface{}](k.leafFrom(root.children[1].(*leafNode[K, interface{}]), left...)))
+
+			if got, want := root.runts[1], k(rightRunt); got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+			ensureNode(t, root.children[1], node[K, interface{}](k.leafFrom(nil, right...)))
+		}
+		t.Run("when new key will be first node in left leaf", func(t *testing.T) {
+			d := gimme()
+			d.Insert(k(0), k(0))
+			ensureRoot(t, d, 0, []int{0, 10, 20}, 30, []int{30, 40})
+		})
+		t.Run("when new key is in middle", func(t *testing.T) {
+			d := gimme()
+			d.Insert(k(25), k(25))
+			ensureRoot(t, d, 10, []int{10, 20, 25}, 30, []int{30, 40})
+		})
+		t.Run("when new key will be final node in right leaf", func(t *testing.T) {
+			d := gimme()
+			d.Insert(k(50), k(50))
+			ensureRoot(t, d, 10, []int{10, 20}, 30, []int{30, 40, 50})
+		})
+	})
+}
+
+func TestTreeSearch(t *testing.T) {
+	forEachKeyType(t, testTreeSearch[int32], testTreeSearch[int64], testTreeSearch[uint32], testTreeSearch[uint64], testTreeSearch[string])
+}
+
+func testTreeSearch[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](16)
+
+		_, ok := d.Search(k(13))
+		if got, want := ok, false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+
+	for _, order := range []int{16, 4} {
+		name := "single-leaf tree"
+		if order == 4 {
+			name = "multi-leaf tree"
+		}
+		t.Run(name, func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](order)
+			for i := 0; i < 15; i++ {
+				if i != 13 {
+					d.Insert(k(i), k(i))
+				}
+			}
+
+			t.Run("missing value", func(t *testing.T) {
+				_, ok := d.Search(k(13))
+				if got, want := ok, false; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("existing value", func(t *testing.T) {
+				value, ok := d.Search(k(8))
+				if got, want := ok, true; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := value, interface{}(k(8)); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestTreeMinMax(t *testing.T) {
+	forEachKeyType(t, testTreeMinMax[int32], testTreeMinMax[int64], testTreeMinMax[uint32], testTreeMinMax[uint64], testTreeMinMax[string])
+}
+
+func testTreeMinMax[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](16)
+
+		if _, _, ok := d.Min(); ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, false)
+		}
+		if _, _, ok := d.Max(); ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, false)
+		}
+	})
+
+	for _, order := range []int{16, 4} {
+		name := "single-leaf tree"
+		if order == 4 {
+			name = "multi-leaf tree"
+		}
+		t.Run(name, func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](order)
+			for _, v := range []int{8, 3, 12, 5, 14, 1, 9, 11, 2, 13} {
+				d.Insert(k(v), v)
+			}
+
+			t.Run("min", func(t *testing.T) {
+				key, value, ok := d.Min()
+				if got, want := ok, true; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := key, k(1); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := value, interface{}(1); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+			t.Run("max", func(t *testing.T) {
+				key, value, ok := d.Max()
+				if got, want := ok, true; got != want {
+					t.Fatalf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := key, k(14); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := value, interface{}(14); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+			})
+		})
+	}
+}
+
+func TestTreeNeighbors(t *testing.T) {
+	forEachKeyType(t, testTreeNeighbors[int32], testTreeNeighbors[int64], testTreeNeighbors[uint32], testTreeNeighbors[uint64], testTreeNeighbors[string])
+}
+
+func testTreeNeighbors[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	type lookup func(K) (K, interface{}, bool)
+
+	// ensureLookup ensures the lookup returns the expected key, or when
+	// expected is negative, that it finds no key.
+	ensureLookup := func(t *testing.T, name string, fn lookup, key, expected int) {
+		t.Helper()
+		gotKey, gotValue, ok := fn(k(key))
+		if got, want := ok, expected >= 0; got != want {
+			t.Errorf("%s(%v) GOT: %v; WANT: %v", name, key, got, want)
+			return
+		}
+		if !ok {
+			return
+		}
+		if got, want := gotKey, k(expected); got != want {
+			t.Errorf("%s(%v) GOT: %v; WANT: %v", name, key, got, want)
+		}
+		if got, want := gotValue, interface{}(k(expected)); got != want {
+			t.Errorf("%s(%v) GOT: %v; WANT: %v", name, key, got, want)
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureLookup(t, "Ceiling", d.Ceiling, 13, -1)
+		ensureLookup(t, "Floor", d.Floor, 13, -1)
+		ensureLookup(t, "Higher", d.Higher, 13, -1)
+		ensureLookup(t, "Lower", d.Lower, 13, -1)
+	})
+
+	t.Run("every key", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 2; i <= 60; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+		for i := 0; i <= 62; i++ {
+			ceiling, floor, higher, lower := -1, -1, -1, -1
+			for j := 2; j <= 60; j += 2 {
+				if j >= i && ceiling < 0 {
+					ceiling = j
+				}
+				if j > i && higher < 0 {
+					higher = j
+				}
+				if j <= i {
+					floor = j
+				}
+				if j < i {
+					lower = j
+				}
+			}
+			ensureLookup(t, "Ceiling", d.Ceiling, i, ceiling)
+			ensureLookup(t, "Floor", d.Floor, i, floor)
+			ensureLookup(t, "Higher", d.Higher, i, higher)
+			ensureLookup(t, "Lower", d.Lower, i, lower)
+		}
+	})
+
+	t.Run("runts smaller than keys", func(t *testing.T) {
+		// After its smallest key is deleted, the runt for a node is smaller
+		// than every key under that node, so the leaf where a key belongs
+		// might not hold the floor of that key.
+		leafD := k.leafFrom(nil, 40, 42, 44)
+		leafC := k.leafFrom(leafD, 30, 32, 34)
+		leafB := k.leafFrom(leafC, 20, 22, 24)
+		leafA := k.leafFrom(leafB, 10, 12, 14)
+		left := internalFrom[K](leafA, leafB)
+		right := internalFrom[K](leafC, leafD)
+		root := internalFrom[K](left, right)
+		left.runts[1] = k(16)
+		right.runts[0] = k(26)
+		root.runts[1] = k(26)
+
+		d := &Tree[K, interface{}]{root: root, compare: cmp.Compare[K], order: 4}
+
+		ensureLookup(t, "Floor", d.Floor, 9, -1)
+		ensureLookup(t, "Floor", d.Floor, 18, 14)
+		ensureLookup(t, "Floor", d.Floor, 28, 24)
+		ensureLookup(t, "Floor", d.Floor, 36, 34)
+		ensureLookup(t, "Lower", d.Lower, 20, 14)
+		ensureLookup(t, "Lower", d.Lower, 30, 24)
+		ensureLookup(t, "Ceiling", d.Ceiling, 15, 20)
+		ensureLookup(t, "Ceiling", d.Ceiling, 17, 20)
+		ensureLookup(t, "Ceiling", d.Ceiling, 27, 30)
+		ensureLookup(t, "Ceiling", d.Ceiling, 45, -1)
+		ensureLookup(t, "Higher", d.Higher, 24, 30)
+		ensureLookup(t, "Higher", d.Higher, 44, -1)
+	})
+}
+
+func TestTreeCursor(t *testing.T) {
+	forEachKeyType(t, testTreeCursor[int32], testTreeCursor[int64], testTreeCursor[uint32], testTreeCursor[uint64], testTreeCursor[string])
+}
+
+func testTreeCursor[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureScan(t, d, k(0), nil)
+	})
+	t.Run("single-leaf tree", func(t *testing.T) {
+		t.Run("scan for zero-value element", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](16)
+			for i := 0; i < 15; i++ {
+				d.Insert(k(i), k(i))
+			}
+			ensureScan(t, d, k(0), k.slice(0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14))
+		})
+		t.Run("scan for missing element", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](16)
+			for i := 0; i < 15; i++ {
+				if i != 13 {
+					d.Insert(k(i), k(i))
+				}
+			}
+			ensureScan(t, d, k(13), k.slice(14))
+		})
+		t.Run("scan for existing element", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](16)
+			for i := 0; i < 15; i++ {
+				d.Insert(k(i), k(i))
+			}
+			ensureScan(t, d, k(13), k.slice(13, 14))
+		})
+		t.Run("scan past final element", func(t *testing.T) {
+			d, _ := NewTree[K, interface{}](16)
+			for i := 0; i < 15; i++ {
+				d.Insert(k(i), k(i))
+			}
+			ensureScan(t, d, k(15), nil)
+		})
+	})
+	t.Run("multi-leaf tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+
+		t.Run("scan for zero-value element", func(t *testing.T) {
+			ensureScan(t, d, k(0), k.slice(0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 28))
+		})
+		t.Run("scan for missing elements", func(t *testing.T) {
+			// Every odd key is missing, and some of them are larger than every
+			// key in the leaf where a search for them ends.
+			for i := 1; i < 29; i += 2 {
+				var expected []int
+				for j := i + 1; j < 30; j += 2 {
+					expected = append(expected, j)
+				}
+				ensureScan(t, d, k(i), k.slice(expected...))
+			}
+		})
+	})
+}
+
+func TestTreeBufferedCursor(t *testing.T) {
+	forEachKeyType(t, testTreeBufferedCursor[int32], testTreeBufferedCursor[int64], testTreeBufferedCursor[uint32], testTreeBufferedCursor[uint64], testTreeBufferedCursor[string])
+}
+
+func testTreeBufferedCursor[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureBufferedScan := func(t *testing.T, d *Tree[K, interface{}], key K, expected []K) {
+		t.Helper()
+
+		var keys []K
+		c := d.NewBufferedScanner(key)
+		for c.Scan() {
+			k, v := c.Pair()
+			if got, want := v, interface{}(k); got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+			keys = append(keys, k)
+		}
+		if got, want := fmt.Sprint(keys), fmt.Sprint(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureBufferedScan(t, d, k(0), nil)
+	})
+	t.Run("multi-leaf tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+		for i := 0; i < 31; i++ {
+			var expected []int
+			for j := i + i%2; j < 30; j += 2 {
+				expected = append(expected, j)
+			}
+			ensureBufferedScan(t, d, k(i), k.slice(expected...))
+		}
+	})
+	t.Run("does not hold lock", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+
+		c := d.NewBufferedScanner(k(0))
+		if got, want := c.Scan(), true; got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+
+		// None of these would return if the cursor held the lock on its leaf.
+		d.Insert(k(1), k(1))   // before the key returned, so not returned
+		d.Delete(k(2))         // already copied, so still returned
+		d.Insert(k(27), k(27)) // after the buffer, so returned
+		d.Delete(k(28))        // after the buffer, so not returned
+
+		keys := []K{k(0)}
+		for c.Scan() {
+			k, _ := c.Pair()
+			keys = append(keys, k)
+		}
+		expected := k.slice(0, 2, 4, 6, 8, 10, 12, 14, 16, 18, 20, 22, 24, 26, 27)
+		if got, want := fmt.Sprint(keys), fmt.Sprint(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("close", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+
+		c := d.NewBufferedScanner(k(0))
+		c.Scan()
+		_ = c.Close()
+		if got, want := c.Scan(), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("concurrent with modifications", func(t *testing.T) {
+		const keyCount = 2000
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < keyCount; i++ {
+				if i%2 == 0 {
+					d.Delete(k(i))
+				} else {
+					d.Insert(k(i), k(i))
+				}
+			}
+		}()
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var previous K
+				c := d.NewBufferedScanner(k(0))
+				for n := 0; c.Scan(); n++ {
+					key, _ := c.Pair()
+					if n > 0 && key <= previous {
+						t.Errorf("GOT: %v after %v; WANT: ascending", key, previous)
+					}
+					previous = key
+				}
+			}()
+		}
+		wg.Wait()
+	})
+}
+
+func TestTreeRangeCursor(t *testing.T) {
+	forEachKeyType(t, testTreeRangeCursor[int32], testTreeRangeCursor[int64], testTreeRangeCursor[uint32], testTreeRangeCursor[uint64], testTreeRangeCursor[string])
+}
+
+func testTreeRangeCursor[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureRangeScan := func(t *testing.T, d *Tree[K, interface{}], lo, hi int, opts RangeOptions, expected []K) {
+		t.Helper()
+
+		var keys []K
+		c := d.NewRangeScanner(k(lo), k(hi), opts)
+		for c.Scan() {
+			k, _ := c.Pair()
+			keys = append(keys, k)
+		}
+		if got, want := fmt.Sprint(keys), fmt.Sprint(expected); got != want {
+			t.Errorf("[%v, %v] %+v GOT: %v; WANT: %v", lo, hi, opts, got, want)
+		}
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureRangeScan(t, d, 0, 99, RangeOptions{}, nil)
+	})
+
+	t.Run("multi-leaf tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 2; i <= 40; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+
+		for _, opts := range []RangeOptions{{}, {ExcludeLo: true}, {ExcludeHi: true}, {ExcludeLo: true, ExcludeHi: true}} {
+			for lo := 0; lo <= 42; lo++ {
+				for hi := lo - 1; hi <= 42; hi++ {
+					if hi < 0 {
+						continue
+					}
+					var expected []int
+					for j := 2; j <= 40; j += 2 {
+						if j < lo || (j == lo && opts.ExcludeLo) || j > hi || (j == hi && opts.ExcludeHi) {
+							continue
+						}
+						expected = append(expected, j)
+					}
+					ensureRangeScan(t, d, lo, hi, opts, k.slice(expected...))
+				}
+			}
+		}
+	})
+
+	t.Run("releases leaf after hi", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 2; i <= 40; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+
+		c := d.NewRangeScanner(k(10), k(20), RangeOptions{})
+		for c.Scan() {
+		}
+		// Would deadlock if the cursor still held the lock on the leaf with
+		// the key after hi.
+		d.Insert(k(21), k(21))
+		d.Delete(k(22))
+
+		if got, want := c.Scan(), false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+}
+
+func TestTreeReverseCursor(t *testing.T) {
+	forEachKeyType(t, testTreeReverseCursor[int32], testTreeReverseCursor[int64], testTreeReverseCursor[uint32], testTreeReverseCursor[uint64], testTreeReverseCursor[string])
+}
+
+func testTreeReverseCursor[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureReverseScan(t, d, k(99), nil)
+	})
+	t.Run("single-leaf tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](16)
+		for i := 1; i < 15; i++ {
+			if i != 13 {
+				d.Insert(k(i), k(i))
+			}
+		}
+		t.Run("scan from past final element", func(t *testing.T) {
+			ensureReverseScan(t, d, k(99), k.slice(14, 12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1))
+		})
+		t.Run("scan for missing element", func(t *testing.T) {
+			ensureReverseScan(t, d, k(13), k.slice(12, 11, 10, 9, 8, 7, 6, 5, 4, 3, 2, 1))
+		})
+		t.Run("scan for existing element", func(t *testing.T) {
+			ensureReverseScan(t, d, k(3), k.slice(3, 2, 1))
+		})
+		t.Run("scan before first element", func(t *testing.T) {
+			ensureReverseScan(t, d, k(0), nil)
+		})
+	})
+	t.Run("multi-leaf tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+
+		t.Run("scan from past final element", func(t *testing.T) {
+			ensureReverseScan(t, d, k(99), k.slice(28, 26, 24, 22, 20, 18, 16, 14, 12, 10, 8, 6, 4, 2, 0))
+		})
+		t.Run("scan for every element", func(t *testing.T) {
+			for i := 0; i < 30; i++ {
+				var expected []int
+				for j := i - i%2; j >= 0; j -= 2 {
+					expected = append(expected, j)
+				}
+				ensureReverseScan(t, d, k(i), k.slice(expected...))
+			}
+		})
+		t.Run("close releases leaf", func(t *testing.T) {
+			c := d.NewReverseScanner(k(15))
+			if got, want := c.Scan(), true; got != want {
+				t.Fatalf("GOT: %v; WANT: %v", got, want)
+			}
+			if got, _ := c.Pair(); got != k(14) {
+				t.Errorf("GOT: %v; WANT: %v", got, k(14))
+			}
+			_ = c.Close()
+			// Would deadlock if the cursor still held the lock on its leaf.
+			d.Insert(k(13), k(13))
+			d.Delete(k(13))
+		})
+	})
+	t.Run("previous leaf is busy", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < 15; i++ {
+			d.Insert(k(2*i), k(2*i))
+		}
+
+		// Hold the lock on the leaf before the one where the reverse cursor
+		// starts, so the cursor must release its leaf and search again rather
+		// than wait for the lock.
+		l := d.lockLeafForSearch(k(14))
+		p := l.prev.Load()
+		l.unlock()
+		p.lock()
+
+		c := d.NewReverseScanner(l.runts[0])
+		if got, want := c.Scan(), true; got != want {
+			t.Fatalf("GOT: %v; WANT: %v", got, want)
+		}
+
+		done := make(chan []K)
+		go func() {
+			var keys []K
+			for c.Scan() {
+				k, _ := c.Pair()
+				keys = append(keys, k)
+			}
+			done <- keys
+		}()
+
+		// Release the previous leaf, allowing the cursor's search to finish.
+		p.unlock()
+		keys := <-done
+
+		var expected []K
+		for i := 0; k(2*i) < l.runts[0]; i++ {
+			expected = append([]K{k(2 * i)}, expected...)
+		}
+		if got, want := fmt.Sprint(keys), fmt.Sprint(expected); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("concurrent with ascending scans", func(t *testing.T) {
+		const keyCount = 2000
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+
+		var wg sync.WaitGroup
+		ensureOrdered := func(c *Cursor[K, interface{}], ascending bool) {
+			defer wg.Done()
+			var previous K
+			for n := 0; c.Scan(); n++ {
+				key, _ := c.Pair()
+				if n > 0 && (key > previous) != ascending {
+					t.Errorf("GOT: %v after %v; WANT: ascending %v", key, previous, ascending)
+				}
+				previous = key
+			}
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i < keyCount; i += 2 {
+				d.Insert(k(i), k(i))
+			}
+		}()
+		for g := 0; g < 4; g++ {
+			wg.Add(2)
+			go ensureOrdered(d.NewScanner(k(0)), true)
+			go ensureOrdered(d.NewReverseScanner(k(keyCount)), false)
+		}
+		wg.Wait()
+
+		ensureLeafLinks(t, d)
+	})
+	t.Run("concurrent with deletes", func(t *testing.T) {
+		const keyCount = 2000
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < keyCount; i++ {
+			d.Insert(k(i), k(i))
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i < keyCount; i += 2 {
+				d.Delete(k(i))
+			}
+		}()
+		for g := 0; g < 4; g++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				var previous K
+				c := d.NewReverseScanner(k(keyCount))
+				for n := 0; c.Scan(); n++ {
+					key, _ := c.Pair()
+					if n > 0 && key >= previous {
+						t.Errorf("GOT: %v after %v; WANT: descending", key, previous)
+					}
+					previous = key
+				}
+			}()
+		}
+		wg.Wait()
+
+		ensureLeafLinks(t, d)
+		var expected []int
+		for i := keyCount - 2; i >= 0; i -= 2 {
+			expected = append(expected, i)
+		}
+		ensureReverseScan(t, d, k(keyCount), k.slice(expected...))
+	})
+
+	t.Run("concurrent with inserts and deletes", func(t *testing.T) {
+		const keyCount = 256
+		const writers = 4
+		const readers = 4
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), k(i))
+		}
+
+		// Writers split and merge leaves while readers move across them
+		// through their prev pointers.
+		var writing sync.WaitGroup
+		writing.Add(writers)
+		for g := 0; g < writers; g++ {
+			go func(g int) {
+				defer writing.Done()
+				r := rand.New(rand.NewSource(int64(g)))
+				for n := 0; n < 10000; n++ {
+					if key := r.Intn(keyCount); r.Intn(2) == 0 {
+						d.Insert(k(key), k(key))
+					} else {
+						d.Delete(k(key))
+					}
+				}
+			}(g)
+		}
+		done := make(chan struct{})
+		go func() {
+			writing.Wait()
+			close(done)
+		}()
+
+		var wg sync.WaitGroup
+		wg.Add(readers)
+		for g := 0; g < readers; g++ {
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-done:
+						return
+					default:
+					}
+					var previous K
+					var n int
+					for key, value := range d.Descend(k(keyCount)) {
+						if value != key {
+							t.Errorf("GOT: %v; WANT: %v", value, key)
+						}
+						if n > 0 && key >= previous {
+							t.Errorf("GOT: %v after %v; WANT: descending", key, previous)
+						}
+						previous = key
+						n++
+					}
+				}
+			}()
+		}
+		wg.Wait()
+
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+	})
+}
+
+func TestTreeUpdate(t *testing.T) {
+	forEachKeyType(t, testTreeUpdate[int32], testTreeUpdate[int64], testTreeUpdate[uint32], testTreeUpdate[uint64], testTreeUpdate[string])
+}
+
+func testTreeUpdate[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	d, _ := NewTree[K, interface{}](8)
+	d.Update(k(1), func(value interface{}, ok bool) interface{} {
+		if got, want := ok, false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, interface{}(nil); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		return "first"
+	})
+	d.Update(k(1), func(value interface{}, ok bool) interface{} {
+		if got, want := ok, true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, "first"; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		return "second"
+	})
+	value, ok := d.Search(k(1))
+	if got, want := ok, true; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := value, "second"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	d.Insert(k(3), "third")
+	d.Update(k(2), func(value interface{}, ok bool) interface{} {
+		if got, want := ok, false; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := value, interface{}(nil); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		return "fourth"
+	})
+	value, ok = d.Search(k(2))
+	if got, want := ok, true; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := value, "fourth"; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestLeafNodeDelete(t *testing.T) {
+	forEachKeyType(t, testLeafNodeDelete[int32], testLeafNodeDelete[int64], testLeafNodeDelete[uint32], testLeafNodeDelete[uint64], testLeafNodeDelete[string])
+}
+
+func testLeafNodeDelete[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("still big enough", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			key      int
+			deleted  bool
+			expected []int
+		}{
+			{"key is missing", 42, false, []int{11, 21, 31}},
+			{"key is first", 11, true, []int{21, 31}},
+			{"key is middle", 21, true, []int{11, 31}},
+			{"key is last", 31, true, []int{11, 21}},
+		}
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				l := k.leafFrom(nil, 11, 21, 31)
+				value, deleted, tooSmall := l.deleteKey(2, k(c.key), cmp.Compare[K])
+				if got, want := deleted, c.deleted; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				var expectedValue interface{}
+				if c.deleted {
+					expectedValue = k(c.key)
+				}
+				if got, want := value, expectedValue; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if got, want := tooSmall, false; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				ensureLeaf(t, l, k.leafFrom(nil, c.expected...))
+			})
+		}
+	})
+	t.Run("will be too small", func(t *testing.T) {
+		l := k.leafFrom(nil, 11, 21, 31, 41)
+		_, _, tooSmall := l.deleteKey(4, k(21), cmp.Compare[K])
+		if got, want := tooSmall, true; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureLeaf(t, l, k.leafFrom(nil, 11, 31, 41))
+	})
+}
+
+func TestLeafNodeAdoptFrom(t *testing.T) {
+	forEachKeyType(t, testLeafNodeAdoptFrom[int32], testLeafNodeAdoptFrom[int64], testLeafNodeAdoptFrom[uint32], testLeafNodeAdoptFrom[uint64], testLeafNodeAdoptFrom[string])
+}
+
+func testLeafNodeAdoptFrom[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("left", func(t *testing.T) {
+		r := k.leafFrom(nil, 5, 6, 7)
+		l := k.leafFrom(r, 0, 1, 2, 3, 4)
+
+		r.adoptFromLeft(l)
+
+		ensureLeaf(t, l, k.leafFrom(r, 0, 1, 2, 3))
+		ensureLeaf(t, r, k.leafFrom(nil, 4, 5, 6, 7))
+	})
+	t.Run("right", func(t *testing.T) {
+		r := k.leafFrom(nil, 3, 4, 5, 6, 7)
+		l := k.leafFrom(r, 0, 1, 2)
+
+		l.adoptFromRight(r)
+
+		ensureLeaf(t, l, k.leafFrom(r, 0, 1, 2, 3))
+		ensureLeaf(t, r, k.leafFrom(nil, 4, 5, 6, 7))
+	})
+}
+
+// nineLeaves returns nine linked leaves, each holding five keys.
+func (k testKeys[K]) nineLeaves() []*leafNode[K, interface{}] {
+	leaves := make([]*leafNode[K, interface{}], 9)
+	var next *leafNode[K, interface{}]
+	for i := len(leaves) - 1; i >= 0; i-- {
+		base := 10 * (i + 1)
+		next = k.leafFrom(next, base, base+2, base+4, base+6, base+8)
+		leaves[i] = next
+	}
+	return leaves
+}
+
+// internalFromLeaves returns an internal node whose children are the leaves.
+func internalFromLeaves[K cmp.Ordered](leaves ...*leafNode[K, interface{}]) *internalNode[K, interface{}] {
+	items := make([]node[K, interface{}], len(leaves))
+	for i, leaf := range leaves {
+		items[i] = leaf
+	}
+	return internalFrom(items...)
+}
+
+func TestInternalNodeAdoptFrom(t *testing.T) {
+	forEachKeyType(t, testInternalNodeAdoptFrom[int32], testInternalNodeAdoptFrom[int64], testInternalNodeAdoptFrom[uint32], testInternalNodeAdoptFrom[uint64], testInternalNodeAdoptFrom[string])
+}
+
+func testInternalNodeAdoptFrom[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("left", func(t *testing.T) {
+		leaves := k.nineLeaves()
+
+		left := internalFromLeaves(leaves[:6]...)
+		right := internalFromLeaves(leaves[6:]...)
+
+		right.adoptFromLeft(left)
+
+		ensureInternal(t, left, internalFromLeaves(leaves[:5]...))
+		ensureInternal(t, right, internalFromLeaves(leaves[5:]...))
+	})
+	t.Run("right", func(t *testing.T) {
+		leaves := k.nineLeaves()
+
+		left := internalFromLeaves(leaves[:3]...)
+		right := internalFromLeaves(leaves[3:]...)
+
+		left.adoptFromRight(right)
+
+		ensureInternal(t, left, internalFromLeaves(leaves[:4]...))
+		ensureInternal(t, right, internalFromLeaves(leaves[4:]...))
+	})
+}
+
+func TestLeafNodeMergeWithRight(t *testing.T) {
+	forEachKeyType(t, testLeafNodeMergeWithRight[int32], testLeafNodeMergeWithRight[int64], testLeafNodeMergeWithRight[uint32], testLeafNodeMergeWithRight[uint64], testLeafNodeMergeWithRight[string])
+}
+
+func testLeafNodeMergeWithRight[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	leafC := k.leafFrom(nil, 6, 7, 8, 9)
+	leafB := k.leafFrom(leafC, 3, 4, 5)
+	leafA := k.leafFrom(leafB, 0, 1, 2)
+
+	leafA.absorbRight(leafB)
+
+	ensureLeaf(t, leafA, k.leafFrom(leafC, 0, 1, 2, 3, 4, 5))
+
+	if got, want := leafC.prev.Load(), leafA; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+
+	if got, want := len(leafB.runts), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := len(leafB.values), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := leafB.next, (*leafNode[K, interface{}])(nil); got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestInternalNodeMergeWithRight(t *testing.T) {
+	forEachKeyType(t, testInternalNodeMergeWithRight[int32], testInternalNodeMergeWithRight[int64], testInternalNodeMergeWithRight[uint32], testInternalNodeMergeWithRight[uint64], testInternalNodeMergeWithRight[string])
+}
+
+func testInternalNodeMergeWithRight[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	leaves := k.nineLeaves()
+
+	left := internalFromLeaves(leaves[:3]...)
+	right := internalFromLeaves(leaves[3:7]...)
+
+	left.absorbRight(right)
+
+	ensureInternal(t, left, internalFromLeaves(leaves[:7]...))
+
+	if got, want := len(right.runts), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+	if got, want := len(right.children), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestInternalNodeLockChildForDelete(t *testing.T) {
+	forEachKeyType(t, testInternalNodeLockChildForDelete[int32], testInternalNodeLockChildForDelete[int64], testInternalNodeLockChildForDelete[uint32], testInternalNodeLockChildForDelete[uint64], testInternalNodeLockChildForDelete[string])
+}
+
+func testInternalNodeLockChildForDelete[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureEmpty := func(t *testing.T, leaf *leafNode[K, interface{}]) {
+		t.Helper()
+		if got, want := len(leaf.runts), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := len(leaf.values), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	// ensureDelete removes key from the leaf returned by lockChildForDelete,
+	// like Delete does, and ensures the node is left with the expected number
+	// of children.
+	ensureDelete := func(t *testing.T, i *internalNode[K, interface{}], key, children int) {
+		t.Helper()
+		child, index := i.lockChildForDelete(4, k(key), cmp.Compare[K])
+		defer child.unlock()
+		if got, want := child, i.children[index]; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if _, deleted, _ := child.(*leafNode[K, interface{}]).deleteKey(4, k(key), cmp.Compare[K]); !deleted {
+			t.Errorf("GOT: %v; WANT: %v", deleted, true)
+		}
+		if got, want := len(i.children), children; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	t.Run("not too small", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56, 58)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36, 38)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26, 28)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16, 18)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD)
+
+		ensureDelete(t, child, 22, 4)
+	})
+	t.Run("child absorbs right when no left and skinny right", func(t *testing.T) {
+		t.Run("child not too small", func(t *testing.T) {
+			leafE := k.leafFrom(nil, 50, 52, 54, 56, 58)
+			leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+			leafC := k.leafFrom(leafD, 30, 32, 34, 36, 38)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+			ensureDelete(t, child, 12, 4)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafC, 10, 14, 16, 20, 22, 24, 26))
+			ensureEmpty(t, leafB)
+			ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36, 38))
+			ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46, 48))
+			ensureLeaf(t, leafE, k.leafFrom(nil, 50, 52, 54, 56, 58))
+		})
+		t.Run("child too small", func(t *testing.T) {
+			leafD := k.leafFrom(nil, 40, 42, 44, 46, 48)
+			leafC := k.leafFrom(leafD, 30, 32, 34, 36, 38)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC, leafD)
+
+			ensureDelete(t, child, 12, 3)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafC, 10, 14, 16, 20, 22, 24, 26))
+			ensureEmpty(t, leafB)
+			ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36, 38))
+			ensureLeaf(t, leafD, k.leafFrom(nil, 40, 42, 44, 46, 48))
+		})
+	})
+	t.Run("child adopts from right when no left and fat right", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56, 58)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36, 38)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26, 28)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+		ensureDelete(t, child, 12, 5)
+
+		ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 14, 16, 20))
+		ensureLeaf(t, leafB, k.leafFrom(leafC, 22, 24, 26, 28))
+		ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36, 38))
+		ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46, 48))
+		ensureLeaf(t, leafE, k.leafFrom(nil, 50, 52, 54, 56, 58))
+		if got, want := child.runts[1], k(22); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("left absorbs child when skinny left and no right", func(t *testing.T) {
+		t.Run("too small", func(t *testing.T) {
+			leafD := k.leafFrom(nil, 40, 42, 44, 46)
+			leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC, leafD)
+
+			ensureDelete(t, child, 42, 3)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16))
+			ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 22, 24, 26))
+			ensureLeaf(t, leafC, k.leafFrom(nil, 30, 32, 34, 36, 40, 44, 46))
+			ensureEmpty(t, leafD)
+		})
+		t.Run("not too small", func(t *testing.T) {
+			leafE := k.leafFrom(nil, 50, 52, 54, 56)
+			leafD := k.leafFrom(leafE, 40, 42, 44, 46)
+			leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+			ensureDelete(t, child, 52, 4)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16))
+			ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 22, 24, 26))
+			ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36))
+			ensureLeaf(t, leafD, k.leafFrom(nil, 40, 42, 44, 46, 50, 54, 56))
+			ensureEmpty(t, leafE)
+		})
+	})
+	t.Run("left absorbs child when skinny left and skinny right", func(t *testing.T) {
+		t.Run("too small", func(t *testing.T) {
+			leafC := k.leafFrom(nil, 30, 32, 34, 36)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC)
+
+			ensureDelete(t, child, 22, 2)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafC, 10, 12, 14, 16, 20, 24, 26))
+			ensureEmpty(t, leafB)
+			ensureLeaf(t, leafC, k.leafFrom(nil, 30, 32, 34, 36))
+		})
+		t.Run("not too small", func(t *testing.T) {
+			leafE := k.leafFrom(nil, 50, 52, 54, 56)
+			leafD := k.leafFrom(leafE, 40, 42, 44, 46)
+			leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+			leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+			leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+			child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+			ensureDelete(t, child, 22, 4)
+
+			ensureLeaf(t, leafA, k.leafFrom(leafC, 10, 12, 14, 16, 20, 24, 26))
+			ensureEmpty(t, leafB)
+			ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36))
+			ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46))
+		})
+	})
+	t.Run("child adopts from right when skinny left and fat right", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56, 58)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36, 38)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+		ensureDelete(t, child, 22, 5)
+
+		ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16))
+		ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 24, 26, 30))
+		ensureLeaf(t, leafC, k.leafFrom(leafD, 32, 34, 36, 38))
+		ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46, 48))
+		ensureLeaf(t, leafE, k.leafFrom(nil, 50, 52, 54, 56, 58))
+	})
+	t.Run("child adopts from left when fat left and no right", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+		ensureDelete(t, child, 52, 5)
+
+		ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16))
+		ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 22, 24, 26))
+		ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 32, 34, 36))
+		ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46))
+		ensureLeaf(t, leafE, k.leafFrom(nil, 48, 50, 54, 56))
+		// The runt for the child must follow the key it adopted, otherwise
+		// searches for that key would descend into the left sibling.
+		if got, want := child.runts[4], k(48); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("child adopts from left when fat left and skinny right", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26, 28)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+		ensureDelete(t, child, 32, 5)
+
+		ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16))
+		ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 22, 24, 26))
+		ensureLeaf(t, leafC, k.leafFrom(leafD, 28, 30, 34, 36))
+		ensureLeaf(t, leafD, k.leafFrom(leafE, 40, 42, 44, 46))
+		ensureLeaf(t, leafE, k.leafFrom(nil, 50, 52, 54, 56))
+		if got, want := child.runts[2], k(28); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	})
+	t.Run("child adopts from right when fat left and fat right", func(t *testing.T) {
+		leafE := k.leafFrom(nil, 50, 52, 54, 56, 58)
+		leafD := k.leafFrom(leafE, 40, 42, 44, 46, 48)
+		leafC := k.leafFrom(leafD, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26, 28)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16, 18)
+
+		child := internalFromLeaves(leafA, leafB, leafC, leafD, leafE)
+
+		ensureDelete(t, child, 32, 5)
+
+		ensureLeaf(t, leafA, k.leafFrom(leafB, 10, 12, 14, 16, 18))
+		ensureLeaf(t, leafB, k.leafFrom(leafC, 20, 22, 24, 26, 28))
+		ensureLeaf(t, leafC, k.leafFrom(leafD, 30, 34, 36, 40))
+		ensureLeaf(t, leafD, k.leafFrom(leafE, 42, 44, 46, 48))
+		ensureLeaf(t, leafE, k.leafFrom(nil, 50, 52, 54, 56, 58))
+	})
+}
+
+func TestTreeDelete(t *testing.T) {
+	forEachKeyType(t, testTreeDelete[int32], testTreeDelete[int64], testTreeDelete[uint32], testTreeDelete[uint64], testTreeDelete[string])
+}
+
+func testTreeDelete[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const order = 32
+
+	d, err := NewTree[K, interface{}](order)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, v := range randomizedValues {
+		d.Insert(k(v), k(v))
+	}
+
+	for _, v := range randomizedValues {
+		if _, ok := d.Search(k(v)); !ok {
+			t.Fatalf("GOT: %v; WANT: %v", ok, true)
+		}
+	}
+
+	for _, v := range randomizedValues {
+		d.Delete(k(v))
+	}
+
+	t.Run("empty", func(t *testing.T) {
+		d.Delete(k(13))
+	})
+
+	t.Run("releases each parent before descending", func(t *testing.T) {
+		plain, _ := NewTree[K, interface{}](4)
+		counted, _ := NewOrderStatisticTree[K, interface{}](4)
+		for _, d := range []*Tree[K, interface{}]{plain, counted} {
+			for i := 0; i < 1000; i++ {
+				d.Insert(k(i), i)
+			}
+
+			// Find the first leaf and its parent.
+			var parent *internalNode[K, interface{}]
+			n := d.root
+			for n.isInternal() {
+				parent = n.(*internalNode[K, interface{}])
+				n = parent.children[0]
+			}
+			leaf := n.(*leafNode[K, interface{}])
+
+			leaf.lock()
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				d.Delete(k(0))
+			}()
+			// Wait for Delete to lock the parent of the leaf, which it holds
+			// while it waits for the lock on the leaf.
+			for parent.mutex.TryLock() {
+				parent.mutex.Unlock()
+				runtime.Gosched()
+			}
+
+			root := d.root.(*internalNode[K, interface{}])
+			released := root.mutex.TryLock()
+			if released {
+				root.mutex.Unlock()
+			}
+			// A tree that maintains counts holds every ancestor of the leaf.
+			if got, want := released, !d.counted; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+
+			leaf.unlock()
+			<-done
+			if _, ok := d.Search(k(0)); ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, false)
+			}
+			ensureStructure(t, d)
+			if d.counted {
+				ensureCounts(t, d)
+			}
+		}
+	})
+	t.Run("concurrent with an ascending cursor", func(t *testing.T) {
+		leafC := k.leafFrom(nil, 30, 32, 34, 36)
+		leafB := k.leafFrom(leafC, 20, 22, 24, 26)
+		leafA := k.leafFrom(leafB, 10, 12, 14, 16)
+		leafB.prev.Store(leafA)
+		leafC.prev.Store(leafB)
+		root := internalFromLeaves(leafA, leafB, leafC)
+		d := &Tree[K, interface{}]{root: root, compare: cmp.Compare[K], order: 4}
+		d.length.Store(12)
+
+		// The cursor holds the lock on the first leaf until it moves to the
+		// second, from which Delete removes a key after restoring it with the
+		// help of the first.
+		c := d.NewScanner(k(10))
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			d.Delete(k(22))
+		}()
+		// Wait for Delete to lock the root, which it holds while it waits for
+		// the lock on the first leaf, and let it reach that point.
+		for root.mutex.TryLock() {
+			root.mutex.Unlock()
+			runtime.Gosched()
+		}
+		for i := 0; i < 100; i++ {
+			runtime.Gosched()
+		}
+
+		// Were Delete to hold the lock on the second leaf while waiting for
+		// the first, the cursor could never move to the second.
+		var keys []K
+		for c.Scan() {
+			key, _ := c.Pair()
+			keys = append(keys, key)
+		}
+		<-done
+
+		ensureKeys(t, keys, k.slice(10, 12, 14, 16, 20, 22, 24, 26, 30, 32, 34, 36))
+		ensureScan(t, d, k(0), k.slice(10, 12, 14, 16, 20, 24, 26, 30, 32, 34, 36))
+		ensureLeafLinks(t, d)
+		ensureStructure(t, d)
+	})
+}
+
+func TestTreeInsertDeleteResults(t *testing.T) {
+	forEachKeyType(t, testTreeInsertDeleteResults[int32], testTreeInsertDeleteResults[int64], testTreeInsertDeleteResults[uint32], testTreeInsertDeleteResults[uint64], testTreeInsertDeleteResults[string])
+}
+
+func testTreeInsertDeleteResults[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureResult := func(t *testing.T, value interface{}, ok bool, wantValue interface{}, wantOK bool) {
+		t.Helper()
+		if value != wantValue || ok != wantOK {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, wantValue, wantOK)
+		}
+	}
+
+	d, _ := NewTree[K, interface{}](4)
+	for i := 0; i < 50; i++ {
+		value, replaced := d.Insert(k(i), i)
+		ensureResult(t, value, replaced, nil, false)
+	}
+
+	// Replacing a value returns the value it replaced.
+	value, replaced := d.Insert(k(7), "seven")
+	ensureResult(t, value, replaced, 7, true)
+	value, replaced = d.Insert(k(7), "SEVEN")
+	ensureResult(t, value, replaced, "seven", true)
+
+	// Deleting a key returns its value, but only the first time.
+	value, found := d.Delete(k(7))
+	ensureResult(t, value, found, "SEVEN", true)
+	value, found = d.Delete(k(7))
+	ensureResult(t, value, found, nil, false)
+	value, found = d.Delete(k(99))
+	ensureResult(t, value, found, nil, false)
+
+	for i := 0; i < 50; i++ {
+		if i == 7 {
+			continue
+		}
+		value, found := d.Delete(k(i))
+		ensureResult(t, value, found, i, true)
+	}
+	if got, want := d.Len(), 0; got != want {
+		t.Errorf("GOT: %v; WANT: %v", got, want)
+	}
+}
+
+func TestTreeLen(t *testing.T) {
+	forEachKeyType(t, testTreeLen[int32], testTreeLen[int64], testTreeLen[uint32], testTreeLen[uint64], testTreeLen[string])
+}
+
+func testTreeLen[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	ensureLen := func(t *testing.T, d *Tree[K, interface{}], want int) {
+		t.Helper()
+		if got := d.Len(); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+	}
+
+	t.Run("sequential", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		ensureLen(t, d, 0)
+
+		for i := 0; i < 20; i++ {
+			d.Insert(k(i), i)
+		}
+		ensureLen(t, d, 20)
+
+		// Replacing the value of an existing key does not change the length.
+		d.Insert(k(7), "seven")
+		ensureLen(t, d, 20)
+
+		// Updating an existing key does not change the length, but updating a
+		// missing key creates it.
+		d.Update(k(7), func(value interface{}, ok bool) interface{} { return value })
+		ensureLen(t, d, 20)
+		d.Update(k(42), func(value interface{}, ok bool) interface{} { return value })
+		ensureLen(t, d, 21)
+
+		// Deleting a missing key does not change the length.
+		d.Delete(k(13))
+		d.Delete(k(13))
+		ensureLen(t, d, 20)
+
+		for i := 0; i < 20; i++ {
+			d.Delete(k(i))
+		}
+		ensureLen(t, d, 1)
+		d.Delete(k(42))
+		ensureLen(t, d, 0)
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 1000
+
+		d, _ := NewTree[K, interface{}](8)
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := g; i < goroutines*perGoroutine; i += goroutines {
+					d.Insert(k(i), i)
+					d.Update(k(i), func(value interface{}, ok bool) interface{} { return value })
+				}
+				for i := g; i < goroutines*perGoroutine; i += 2 * goroutines {
+					d.Delete(k(i))
+				}
+			}(g)
+		}
+		wg.Wait()
+
+		ensureLen(t, d, goroutines*perGoroutine/2)
+	})
+}
+
+func TestTreeClear(t *testing.T) {
+	forEachKeyType(t, testTreeClear[int32], testTreeClear[int64], testTreeClear[uint32], testTreeClear[uint64], testTreeClear[string])
+}
+
+func testTreeClear[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	t.Run("sequential", func(t *testing.T) {
+		newTrees := map[string]func() (*Tree[K, interface{}], error){
+			"plain":           func() (*Tree[K, interface{}], error) { return NewTree[K, interface{}](4) },
+			"order statistic": func() (*Tree[K, interface{}], error) { return NewOrderStatisticTree[K, interface{}](4) },
+			"aggregate":       func() (*Tree[K, interface{}], error) { return NewAggregateTree[K, interface{}](4, sumAggregator) },
+		}
+		for name, newTree := range newTrees {
+			t.Run(name, func(t *testing.T) {
+				d, _ := newTree()
+				for i := 0; i < 100; i++ {
+					d.Insert(k(i), i)
+				}
+
+				d.Clear()
+				ensureScan(t, d, k(0), nil)
+				if got, want := d.Len(), 0; got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if _, ok := d.Search(k(7)); ok {
+					t.Errorf("GOT: %v; WANT: %v", ok, false)
+				}
+
+				// The tree remains usable, and keeps maintaining counts or
+				// summaries after it is cleared.
+				var expected []int
+				for i := 50; i < 150; i++ {
+					d.Insert(k(i), i)
+					expected = append(expected, i)
+				}
+				ensureScan(t, d, k(0), k.slice(expected...))
+				ensureStructure(t, d)
+				ensureLeafLinks(t, d)
+				if got, want := d.Len(), len(expected); got != want {
+					t.Errorf("GOT: %v; WANT: %v", got, want)
+				}
+				if d.counted {
+					ensureCounts(t, d)
+				}
+				if d.aggregator != nil {
+					ensureSummaries(t, d)
+				}
+			})
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const goroutines = 8
+		const perGoroutine = 500
+
+		d, _ := NewTree[K, interface{}](4)
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines + 1)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				for i := g; i < goroutines*perGoroutine; i += goroutines {
+					d.Insert(k(i), i)
+					d.Search(k(i))
+					for range d.Ascend(k(i)) {
+						break
+					}
+					if i >= goroutines {
+						d.Delete(k(i - goroutines))
+					}
+				}
+			}(g)
+		}
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 20; n++ {
+				d.Clear()
+			}
+		}()
+		wg.Wait()
+
+		// The count of pairs agrees with the pairs that survived the final
+		// Clear.
+		var count int
+		for range d.All() {
+			count++
+		}
+		if got, want := d.Len(), count; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureStructure(t, d)
+		ensureLeafLinks(t, d)
+	})
+}
+
+func TestTreeRandomizedOperations(t *testing.T) {
+	forEachKeyType(t, testTreeRandomizedOperations[int32], testTreeRandomizedOperations[int64], testTreeRandomizedOperations[uint32], testTreeRandomizedOperations[uint64], testTreeRandomizedOperations[string])
+}
+
+// testTreeRandomizedOperations applies a random mix of insertions and
+// deletions to a small order tree, which frequently splits, merges, and
+// rebalances its nodes, and compares the tree with a map after every step.
+func testTreeRandomizedOperations[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const keyCount = 256
+
+	r := rand.New(rand.NewSource(1))
+	d, _ := NewTree[K, interface{}](4)
+	m := make(map[int]bool)
+
+	for step := 0; step < 2000; step++ {
+		v := r.Intn(keyCount)
+		if r.Intn(3) == 0 {
+			d.Delete(k(v))
+			delete(m, v)
+		} else {
+			d.Insert(k(v), k(v))
+			m[v] = true
+		}
+
+		var expected []int
+		for i := 0; i < keyCount; i++ {
+			_, ok := d.Search(k(i))
+			if got, want := ok, m[i]; got != want {
+				t.Fatalf("STEP: %d; KEY: %v; GOT: %v; WANT: %v", step, i, got, want)
+			}
+			if ok {
+				expected = append(expected, i)
+			}
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		ensureLeafLinks(t, d)
+		if got, want := d.Len(), len(m); got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if minKey, _, ok := d.Min(); ok != (len(expected) > 0) || ok && minKey != k(expected[0]) {
+			t.Errorf("GOT: %v, %v; WANT: %v", minKey, ok, expected)
+		}
+		if maxKey, _, ok := d.Max(); ok != (len(expected) > 0) || ok && maxKey != k(expected[len(expected)-1]) {
+			t.Errorf("GOT: %v, %v; WANT: %v", maxKey, ok, expected)
+		}
+		if t.Failed() {
+			t.Fatalf("STEP: %d", step)
+		}
+	}
+}
+
+func benchmarkTree[K cmp.Ordered](b *testing.B, order int, values []int, k testKeys[K]) {
+	var d *Tree[K, interface{}]
+	var err error
+
+	b.Run("insert", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d, err = NewTree[K, interface{}](order)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for _, v := range values {
+				d.Insert(k(v), k(v))
+			}
+		}
+	})
+
+	b.Run("search", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				if _, ok := d.Search(k(v)); !ok {
+					b.Fatalf("GOT: %v; WANT: %v", ok, true)
+				}
+			}
+		}
+	})
+
+	b.Run("scan", func(b *testing.B) {
+		var ignored int
+		for i := 0; i < b.N; i++ {
+			var count int
+			scanner := d.NewScanner(k(0))
+			for scanner.Scan() {
+				count++
+			}
+			ignored = count
+		}
+		_ = ignored
+	})
+
+	b.Run("delete", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			for _, v := range values {
+				d.Delete(k(v))
+			}
+		}
+	})
+}
+
+func uint32Key(i int) uint32 { return uint32(i) }
+
+func uint64Key(i int) uint64 { return uint64(i) }
+
+func BenchmarkUint32Order16(b *testing.B) {
+	const order = 16
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint32Order32(b *testing.B) {
+	const order = 32
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint32Order64(b *testing.B) {
+	const order = 64
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint32Order128(b *testing.B) {
+	const order = 128
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint32Order256(b *testing.B) {
+	const order = 256
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint32Order512(b *testing.B) {
+	const order = 512
+	benchmarkTree(b, order, randomizedValues, uint32Key)
+}
+
+func BenchmarkUint64Order16(b *testing.B) {
+	const order = 16
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
+
+func BenchmarkUint64Order32(b *testing.B) {
+	const order = 32
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
+
+func BenchmarkUint64Order64(b *testing.B) {
+	const order = 64
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
+
+func BenchmarkUint64Order128(b *testing.B) {
+	const order = 128
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
+
+func BenchmarkUint64Order256(b *testing.B) {
+	const order = 256
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
+
+func BenchmarkUint64Order512(b *testing.B) {
+	const order = 512
+	benchmarkTree(b, order, randomizedValues, uint64Key)
+}
@@ -0,0 +1,282 @@
+package gobptree
+
+import (
+	"iter"
+	"slices"
+)
+
+// Tx is an optimistic transaction on a tree, which reads a version of the tree
+// taken when the transaction began, and buffers its modifications until it
+// commits. A Tx is only valid until the function passed to Txn returns.
+type Tx[K any, V any] struct {
+	t      *Tree[K, V]
+	v      *ReadView[K, V]
+	writes []txWrite[K, V] // sorted by key
+	reads  []txRead[K]
+}
+
+// txWrite is a modification buffered by a transaction.
+type txWrite[K any, V any] struct {
+	key    K
+	value  V
+	delete bool
+}
+
+// txRead is a range of keys read by a transaction from the version of the
+// tree, which is unbounded above when hasHi is false.
+type txRead[K any] struct {
+	lo, hi K
+	hasLo  bool
+	hasHi  bool
+}
+
+// Txn invokes fn with a transaction, whose Get, Put, Delete, and scanning
+// methods behave as though fn had the tree to itself, and then commits the
+// modifications fn made with Put and Delete to the tree all at once, like
+// Apply. When fn returns an error, Txn discards the modifications and returns
+// the error.
+//
+// Transactions are optimistic. The transaction reads a version of the tree,
+// like View, and records every key and range of keys it reads. When it
+// commits, Txn waits for the operations modifying the tree to complete, and
+// checks that none of the leaf nodes holding those keys was modified since
+// the version was taken. When one was, the tree was modified in a way that
+// might have changed what fn read, so Txn discards the modifications and
+// invokes fn again with a new transaction, until a transaction commits. So fn
+// may be invoked more than once, and must have no effect other than on the
+// transaction.
+//
+// Because each transaction reads a version of the tree like View, the leaf
+// nodes of the tree are not linked while a transaction runs, and are linked
+// again once it returns, as they are after View.
+//
+//	err := t.Txn(func(tx *gobptree.Tx[string, int]) error {
+//	    from, _ := tx.Get("alice")
+//	    if from < amount {
+//	        return errInsufficientFunds
+//	    }
+//	    to, _ := tx.Get("bob")
+//	    tx.Put("alice", from-amount)
+//	    tx.Put("bob", to+amount)
+//	    return nil
+//	})
+func (t *Tree[K, V]) Txn(fn func(tx *Tx[K, V]) error) error {
+	for {
+		if committed, err := t.txn(fn); err != nil || committed {
+			return err
+		}
+	}
+}
+
+// txn invokes fn with a new transaction, and returns true when the
+// transaction commits.
+func (t *Tree[K, V]) txn(fn func(tx *Tx[K, V]) error) (bool, error) {
+	tx := &Tx[K, V]{t: t, v: t.pinView()}
+	defer t.releaseView(tx.v)
+
+	if err := fn(tx); err != nil {
+		return false, err
+	}
+	return tx.commit(), nil
+}
+
+// commit applies the modifications of the transaction to the tree, unless a
+// leaf node holding a key the transaction read has been modified since the
+// transaction's version of the tree was taken, in which case it returns false.
+func (tx *Tx[K, V]) commit() bool {
+	if len(tx.writes) == 0 {
+		// Every key the transaction read was read from a single version of the
+		// tree, so the transaction is equivalent to reading that version.
+		return true
+	}
+
+	t := tx.t
+	t.writeMutex.Lock()
+	defer t.writeMutex.Unlock()
+
+	t.rootMutex.RLock()
+	root := t.root
+	t.rootMutex.RUnlock()
+
+	// The nodes of the version are shared with the tree, so every leaf of the
+	// version the tree has modified since has been replaced by a copy. No
+	// operation modifies the tree while the write mutex is held exclusively,
+	// so its nodes may be read without acquiring their locks.
+	var before, after []*leafNode[K, V]
+	for _, r := range tx.reads {
+		before = leavesIn(tx.v.root, r, t.compare, before[:0])
+		after = leavesIn(root, r, t.compare, after[:0])
+		if !slices.Equal(before, after) {
+			return false
+		}
+	}
+
+	ops := make([]batchOp[K, V], len(tx.writes))
+	for i, w := range tx.writes {
+		ops[i] = batchOp[K, V]{key: w.key, value: w.value, delete: w.delete}
+	}
+	t.apply(ops)
+	return true
+}
+
+// leavesIn appends to leaves every leaf node under n that might hold a key in
+// the range r.
+func leavesIn[K any, V any](n node[K, V], r txRead[K], compare func(a, b K) int, leaves []*leafNode[K, V]) []*leafNode[K, V] {
+	i, ok := n.(*internalNode[K, V])
+	if !ok {
+		return append(leaves, n.(*leafNode[K, V]))
+	}
+	first, last := 0, len(i.children)-1
+	if r.hasLo {
+		first = searchLessThanOrEqualTo(r.lo, i.runts, compare)
+	}
+	if r.hasHi {
+		last = searchLessThanOrEqualTo(r.hi, i.runts, compare)
+	}
+	if first > last {
+		// The range is empty, because hi is less than lo.
+		return leaves
+	}
+	for _, child := range i.children[first : last+1] {
+		leaves = leavesIn(child, r, compare, leaves)
+	}
+	return leaves
+}
+
+// search returns the index of the buffered modification of key, and true when
+// there is one.
+func (tx *Tx[K, V]) search(key K) (int, bool) {
+	return slices.BinarySearchFunc(tx.writes, key, func(w txWrite[K, V], key K) int {
+		return tx.t.compare(w.key, key)
+	})
+}
+
+// write buffers a modification of the tree.
+func (tx *Tx[K, V]) write(w txWrite[K, V]) {
+	if index, ok := tx.search(w.key); ok {
+		tx.writes[index] = w
+	} else {
+		tx.writes = slices.Insert(tx.writes, index, w)
+	}
+}
+
+// Get returns the value associated with key, as modified by the transaction.
+func (tx *Tx[K, V]) Get(key K) (V, bool) {
+	if index, ok := tx.search(key); ok {
+		w := tx.writes[index]
+		if w.delete {
+			var zero V
+			return zero, false
+		}
+		return w.value, true
+	}
+	tx.reads = append(tx.reads, txRead[K]{lo: key, hi: key, hasLo: true, hasHi: true})
+	return tx.v.Search(key)
+}
+
+// Put associates value with key when the transaction commits.
+func (tx *Tx[K, V]) Put(key K, value V) {
+	tx.write(txWrite[K, V]{key: key, value: value})
+}
+
+// Delete removes key from the tree when the transaction commits.
+func (tx *Tx[K, V]) Delete(key K) {
+	tx.write(txWrite[K, V]{key: key, delete: true})
+}
+
+// All returns an iterator over every key-value pair in the tree in ascending
+// order, as modified by the transaction.
+func (tx *Tx[K, V]) All() iter.Seq2[K, V] {
+	var key K
+	return tx.scan(key, key, false, false, RangeOptions{})
+}
+
+// Ascend returns an iterator over the key-value pairs in the tree in ascending
+// order, as modified by the transaction, starting at key, or if key is not
+// found the next key.
+func (tx *Tx[K, V]) Ascend(key K) iter.Seq2[K, V] {
+	return tx.scan(key, key, true, false, RangeOptions{})
+}
+
+// Range returns an iterator over the key-value pairs in the tree in ascending
+// order, as modified by the transaction, starting at lo and ending at hi, each
+// of which is included unless excluded by opts.
+func (tx *Tx[K, V]) Range(lo, hi K, opts RangeOptions) iter.Seq2[K, V] {
+	return tx.scan(lo, hi, true, true, opts)
+}
+
+// scan returns an iterator over the key-value pairs from lo when hasLo is true
+// through hi when hasHi is true, which merges the modifications the
+// transaction buffered before the iteration began with the pairs of its
+// version of the tree. It records the range of keys it read, which ends at the
+// final key it yielded when the loop breaks early.
+func (tx *Tx[K, V]) scan(lo, hi K, hasLo, hasHi bool, opts RangeOptions) iter.Seq2[K, V] {
+	compare := tx.t.compare
+	// inRange returns true when key is after lo and before hi.
+	inRange := func(key K) bool {
+		if hasLo {
+			if c := compare(key, lo); c < 0 || (c == 0 && opts.ExcludeLo) {
+				return false
+			}
+		}
+		if hasHi {
+			if c := compare(key, hi); c > 0 || (c == 0 && opts.ExcludeHi) {
+				return false
+			}
+		}
+		return true
+	}
+
+	return func(yield func(K, V) bool) {
+		var writes []txWrite[K, V]
+		for _, w := range tx.writes {
+			if inRange(w.key) {
+				writes = append(writes, w)
+			}
+		}
+
+		read := txRead[K]{lo: lo, hi: hi, hasLo: hasLo, hasHi: hasHi}
+		defer func() {
+			tx.reads = append(tx.reads, read)
+		}()
+
+		// emit yields the key-value pair unless the transaction deleted it,
+		// and when yield returns false, ends the range read at key.
+		var stopped bool
+		emit := func(key K, value V, deleted bool) bool {
+			if !deleted && !yield(key, value) {
+				read.hi, read.hasHi = key, true
+				stopped = true
+			}
+			return !stopped
+		}
+
+		tx.v.ascend(tx.v.root, lo, hasLo, !opts.ExcludeLo, func(key K, value V) bool {
+			if !inRange(key) {
+				return false
+			}
+			// Buffered modifications of smaller keys come first.
+			for len(writes) > 0 {
+				c := compare(writes[0].key, key)
+				if c > 0 {
+					break
+				}
+				w := writes[0]
+				writes = writes[1:]
+				if c == 0 {
+					// The modification replaces the pair of the version.
+					return emit(w.key, w.value, w.delete)
+				}
+				if !emit(w.key, w.value, w.delete) {
+					return false
+				}
+			}
+			return emit(key, value, false)
+		})
+		for _, w := range writes {
+			if stopped || !emit(w.key, w.value, w.delete) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package gobptree
+
+import (
+	"cmp"
+	"errors"
+	"math/rand"
+	"sync"
+	"testing"
+)
+
+func TestTreeTxn(t *testing.T) {
+	forEachKeyType(t, testTreeTxn[int32], testTreeTxn[int64], testTreeTxn[uint32], testTreeTxn[uint64], testTreeTxn[string])
+}
+
+func testTreeTxn[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const keyCount = 200
+
+	newTree := func(order int) *Tree[K, interface{}] {
+		d, _ := NewTree[K, interface{}](order)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), i)
+		}
+		return d
+	}
+
+	t.Run("modifications are buffered until commit", func(t *testing.T) {
+		d := newTree(4)
+		err := d.Txn(func(tx *Tx[K, interface{}]) error {
+			tx.Put(k(1), 1)
+			tx.Put(k(2), -2)
+			tx.Delete(k(4))
+			tx.Delete(k(5))
+
+			if value, ok := tx.Get(k(1)); !ok || value != 1 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 1, true)
+			}
+			if value, ok := tx.Get(k(2)); !ok || value != -2 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, -2, true)
+			}
+			if value, ok := tx.Get(k(4)); ok {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, nil, false)
+			}
+			if value, ok := tx.Get(k(6)); !ok || value != 6 {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, 6, true)
+			}
+			// The tree is unchanged until the transaction commits.
+			if value, ok := d.Search(k(1)); ok {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, nil, false)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ensureScan(t, d, k(0), k.slice(func() []int {
+			expected := []int{0, 1, 2}
+			for i := 6; i < keyCount; i += 2 {
+				expected = append(expected, i)
+			}
+			return expected
+		}()...))
+		if value, ok := d.Search(k(2)); !ok || value != -2 {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", value, ok, -2, true)
+		}
+		if got, want := d.Len(), keyCount/2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureStructure(t, d)
+	})
+	t.Run("leaves are linked once the transaction commits", func(t *testing.T) {
+		d := newTree(4)
+		err := d.Txn(func(tx *Tx[K, interface{}]) error {
+			for i := 1; i < keyCount; i += 2 {
+				tx.Put(k(i), i)
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got := d.cow.Load(); got != nil {
+			t.Errorf("GOT: %v; WANT: %v", got, nil)
+		}
+		ensureLeafLinks(t, d)
+		ensureScan(t, d, k(0), k.slice(func() []int {
+			var expected []int
+			for i := 0; i < keyCount; i++ {
+				expected = append(expected, i)
+			}
+			return expected
+		}()...))
+		ensureStructure(t, d)
+	})
+	t.Run("error discards modifications", func(t *testing.T) {
+		d := newTree(4)
+		abort := errors.New("abort")
+		err := d.Txn(func(tx *Tx[K, interface{}]) error {
+			tx.Put(k(1), 1)
+			tx.Delete(k(2))
+			return abort
+		})
+		if err != abort {
+			t.Errorf("GOT: %v; WANT: %v", err, abort)
+		}
+		if _, ok := d.Search(k(1)); ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, false)
+		}
+		if _, ok := d.Search(k(2)); !ok {
+			t.Errorf("GOT: %v; WANT: %v", ok, true)
+		}
+	})
+	t.Run("scans merge modifications", func(t *testing.T) {
+		d := newTree(4)
+		d.Txn(func(tx *Tx[K, interface{}]) error {
+			tx.Put(k(11), 11)
+			tx.Put(k(12), -12)
+			tx.Delete(k(14))
+			tx.Put(k(21), 21)
+			tx.Delete(k(20))
+
+			var keys []K
+			for key, value := range tx.Range(k(10), k(21), RangeOptions{ExcludeLo: true}) {
+				if key == k(12) && value != -12 {
+					t.Errorf("GOT: %v; WANT: %v", value, -12)
+				}
+				keys = append(keys, key)
+			}
+			ensureKeys(t, keys, k.slice(11, 12, 16, 18, 21))
+
+			keys = keys[:0]
+			for key := range tx.Ascend(k(193)) {
+				keys = append(keys, key)
+			}
+			ensureKeys(t, keys, k.slice(194, 196, 198))
+
+			keys = keys[:0]
+			for key := range tx.All() {
+				if keys = append(keys, key); len(keys) == 4 {
+					break
+				}
+			}
+			ensureKeys(t, keys, k.slice(0, 2, 4, 6))
+			return nil
+		})
+	})
+	t.Run("retries on conflict", func(t *testing.T) {
+		d := newTree(4)
+
+		// txn increments the value of the first key, and the first time it
+		// is invoked, modifies the tree with the function provided.
+		txn := func(modify func()) int {
+			var attempts int
+			err := d.Txn(func(tx *Tx[K, interface{}]) error {
+				if attempts++; attempts == 1 {
+					modify()
+				}
+				value, _ := tx.Get(k(0))
+				for range tx.Range(k(10), k(20), RangeOptions{}) {
+				}
+				tx.Put(k(0), value.(int)+1)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return attempts
+		}
+
+		// Modifications of keys the transaction did not read do not conflict.
+		if got, want := txn(func() { d.Insert(k(keyCount), 0) }), 1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		// Modifications of a key the transaction read conflict.
+		if got, want := txn(func() { d.Insert(k(0), 10) }), 2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		// Inserting into a range the transaction scanned conflicts.
+		if got, want := txn(func() { d.Insert(k(15), 0) }), 2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		// Transactions modify the tree too.
+		if got, want := txn(func() { d.Txn(func(tx *Tx[K, interface{}]) error { tx.Delete(k(12)); return nil }) }), 2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if value, _ := d.Search(k(0)); value != 13 {
+			t.Errorf("GOT: %v; WANT: %v", value, 13)
+		}
+		ensureStructure(t, d)
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const accounts = 50
+		const goroutines = 8
+		const transfers = 100
+
+		d, _ := NewTree[K, interface{}](4)
+		for i := 0; i < accounts; i++ {
+			d.Insert(k(i), 100)
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(goroutines + 1)
+		for g := 0; g < goroutines; g++ {
+			go func(g int) {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(int64(g)))
+				for n := 0; n < transfers; n++ {
+					from, to := r.Intn(accounts), r.Intn(accounts)
+					d.Txn(func(tx *Tx[K, interface{}]) error {
+						balance, _ := tx.Get(k(from))
+						tx.Put(k(from), balance.(int)-1)
+						balance, _ = tx.Get(k(to))
+						tx.Put(k(to), balance.(int)+1)
+						return nil
+					})
+					// Every transaction increments the counter once.
+					d.Txn(func(tx *Tx[K, interface{}]) error {
+						count, ok := tx.Get(k(accounts))
+						if !ok {
+							count = 0
+						}
+						tx.Put(k(accounts), count.(int)+1)
+						return nil
+					})
+				}
+			}(g)
+		}
+		go func() {
+			defer wg.Done()
+			for n := 0; n < 100; n++ {
+				// Read-only transactions observe a single version of the
+				// tree, in which the transfers preserve the total.
+				d.Txn(func(tx *Tx[K, interface{}]) error {
+					var total int
+					for _, balance := range tx.Range(k(0), k(accounts), RangeOptions{ExcludeHi: true}) {
+						total += balance.(int)
+					}
+					if got, want := total, accounts*100; got != want {
+						t.Errorf("GOT: %v; WANT: %v", got, want)
+					}
+					return nil
+				})
+			}
+		}()
+		wg.Wait()
+
+		if value, _ := d.Search(k(accounts)); value != goroutines*transfers {
+			t.Errorf("GOT: %v; WANT: %v", value, goroutines*transfers)
+		}
+		var total int
+		for i := 0; i < accounts; i++ {
+			balance, _ := d.Search(k(i))
+			total += balance.(int)
+		}
+		if got, want := total, accounts*100; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		ensureStructure(t, d)
+	})
+}
@@ -0,0 +1,267 @@
+package gobptree
+
+import "iter"
+
+// ReadView is a read-only version of a tree at a single point in time. Its
+// methods acquire no lock on any node, because no node of a version is ever
+// modified. A ReadView is only valid until the function passed to View
+// returns.
+type ReadView[K any, V any] struct {
+	root    node[K, V]
+	length  int
+	compare func(a, b K) int
+	cow     *cowContext // the context of the nodes the tree shared with the version
+	next    *cowContext // the context of the tree after the version was taken
+	readers int         // guarded by the tree's viewMutex
+}
+
+// View invokes fn with a read-only version of the tree, which holds every
+// key-value pair in the tree at a single point in time. Unlike the methods of
+// the tree, the methods of the version acquire no lock on any node, so a long
+// enumeration of the version neither waits for nor delays the operations
+// modifying the tree, which may be invoked by fn itself.
+//
+// Concurrent calls to View share a version until the tree is modified. When
+// the tree has been modified since the last version was taken, View takes a
+// new version, which waits for the operations modifying the tree to complete.
+// Like Clone, a version shares every node with the tree, and the first
+// operation to modify a shared node copies it instead. Once no call to View
+// references a version, the nodes that only the version held are reclaimed by
+// the garbage collector, and the tree resumes modifying the nodes it still
+// shares with the version in place.
+//
+// A leaf shared with a version cannot link to the next leaf of both, so like
+// the cursors of a tree that has been cloned, the cursors of the tree search
+// for each leaf while any version has readers. Once every version is released
+// and no cursor of the tree remains open, the tree links its leaves again,
+// visiting only the nodes it copied in the meantime.
+//
+//	t.View(func(v *gobptree.ReadView[string, int]) {
+//	    for k, n := range v.All() {
+//	        report(k, n) // writers continue to modify t
+//	    }
+//	})
+func (t *Tree[K, V]) View(fn func(v *ReadView[K, V])) {
+	v := t.pinView()
+	defer t.releaseView(v)
+	fn(v)
+}
+
+// pinView returns the version most recently taken when the tree has not been
+// modified since, or else takes a new version, and counts the caller as one
+// of its readers.
+func (t *Tree[K, V]) pinView() *ReadView[K, V] {
+	t.viewMutex.Lock()
+	defer t.viewMutex.Unlock()
+
+	if v := t.view; v != nil && t.viewFresh.Load() {
+		v.readers++
+		return v
+	}
+
+	t.writeMutex.Lock()
+	f := t.frozen()
+	t.versions.Add(1)
+	// Clone, Snapshot, and Apply replace the context of the tree once the
+	// write mutex is released, so the context that adopts the nodes of the
+	// version must be read while it is held.
+	next := t.cow.Load()
+	// Every operation that modifies the tree from now on clears the flag
+	// before it modifies any node.
+	t.viewFresh.Store(true)
+	t.writeMutex.Unlock()
+
+	t.view = &ReadView[K, V]{
+		root:    f.root,
+		length:  int(f.length.Load()),
+		compare: t.compare,
+		cow:     f.cow.Load(),
+		next:    next,
+		readers: 1,
+	}
+	return t.view
+}
+
+// releaseView no longer counts the caller as a reader of the version. When the
+// version has no readers and is the version most recently taken, the tree
+// adopts the nodes it shares with the version, so it may modify them in place
+// again.
+func (t *Tree[K, V]) releaseView(v *ReadView[K, V]) {
+	t.viewMutex.Lock()
+	if v.readers--; v.readers > 0 {
+		t.viewMutex.Unlock()
+		return
+	}
+	if t.view == v {
+		t.view = nil
+		// No call to View may read the version from now on. The nodes of a
+		// tree whose leaves were linked belong to no context, and cannot be
+		// adopted. When the tree was cloned or snapshotted after the version
+		// was taken, its context is no longer the context that adopts the
+		// nodes, so the nodes remain shared with the clone or snapshot.
+		if v.cow != nil {
+			v.cow.adopter.Store(v.next)
+		}
+	}
+	t.viewMutex.Unlock()
+
+	// Once no version has readers, the tree may link its leaves again.
+	if t.versions.Add(-1) == 0 {
+		t.maybeRelink()
+	}
+}
+
+// Len returns the number of key-value pairs in the version.
+func (v *ReadView[K, V]) Len() int { return v.length }
+
+// Search returns the value associated with key from the version.
+func (v *ReadView[K, V]) Search(key K) (V, bool) {
+	n := v.root
+	for {
+		switch x := n.(type) {
+		case *internalNode[K, V]:
+			n = x.children[searchLessThanOrEqualTo(key, x.runts, v.compare)]
+		case *leafNode[K, V]:
+			if index, found := x.search(key, v.compare); found {
+				return x.values[index], true
+			}
+			var zero V
+			return zero, false
+		}
+	}
+}
+
+// Min returns the smallest key in the version along with its value, and false
+// when the version is empty.
+func (v *ReadView[K, V]) Min() (K, V, bool) {
+	for key, value := range v.All() {
+		return key, value, true
+	}
+	var key K
+	var value V
+	return key, value, false
+}
+
+// Max returns the largest key in the version along with its value, and false
+// when the version is empty.
+func (v *ReadView[K, V]) Max() (K, V, bool) {
+	var key K
+	var value V
+	var ok bool
+	v.descend(v.root, key, false, func(k K, val V) bool {
+		key, value, ok = k, val, true
+		return false
+	})
+	return key, value, ok
+}
+
+// All returns an iterator over every key-value pair in the version in
+// ascending order.
+func (v *ReadView[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		var key K
+		v.ascend(v.root, key, false, true, yield)
+	}
+}
+
+// Ascend returns an iterator over the key-value pairs in the version in
+// ascending order, starting at key, or if key is not found the next key.
+func (v *ReadView[K, V]) Ascend(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		v.ascend(v.root, key, true, true, yield)
+	}
+}
+
+// Descend returns an iterator over the key-value pairs in the version in
+// descending order, starting at key, or if key is not found the previous key.
+func (v *ReadView[K, V]) Descend(key K) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		v.descend(v.root, key, true, yield)
+	}
+}
+
+// Range returns an iterator over the key-value pairs in the version in
+// ascending order, starting at lo and ending at hi, each of which is included
+// unless excluded by opts.
+func (v *ReadView[K, V]) Range(lo, hi K, opts RangeOptions) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		v.ascend(v.root, lo, true, !opts.ExcludeLo, func(key K, value V) bool {
+			if c := v.compare(key, hi); c > 0 || (c == 0 && opts.ExcludeHi) {
+				return false
+			}
+			return yield(key, value)
+		})
+	}
+}
+
+// ascend passes the key-value pairs under n to yield in ascending order,
+// starting at lo when hasLo is true, or after lo when inclusive is false, and
+// returns false once yield returns false.
+func (v *ReadView[K, V]) ascend(n node[K, V], lo K, hasLo, inclusive bool, yield func(K, V) bool) bool {
+	if l, ok := n.(*leafNode[K, V]); ok {
+		var first int
+		if hasLo {
+			var found bool
+			if first, found = l.search(lo, v.compare); found && !inclusive {
+				first++
+			}
+		}
+		for index := first; index < len(l.runts); index++ {
+			if !yield(l.runts[index], l.values[index]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	i := n.(*internalNode[K, V])
+	var first int
+	if hasLo {
+		first = searchLessThanOrEqualTo(lo, i.runts, v.compare)
+	}
+	if !v.ascend(i.children[first], lo, hasLo, inclusive, yield) {
+		return false
+	}
+	for _, child := range i.children[first+1:] {
+		if !v.ascend(child, lo, false, inclusive, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+// descend passes the key-value pairs under n to yield in descending order,
+// starting at hi, or if hi is not found the previous key, when hasHi is true,
+// and returns false once yield returns false.
+func (v *ReadView[K, V]) descend(n node[K, V], hi K, hasHi bool, yield func(K, V) bool) bool {
+	if l, ok := n.(*leafNode[K, V]); ok {
+		last := len(l.runts) - 1
+		if hasHi {
+			index, found := l.search(hi, v.compare)
+			if last = index; !found {
+				last--
+			}
+		}
+		for index := last; index >= 0; index-- {
+			if !yield(l.runts[index], l.values[index]) {
+				return false
+			}
+		}
+		return true
+	}
+
+	i := n.(*internalNode[K, V])
+	last := len(i.children) - 1
+	if hasHi {
+		last = searchLessThanOrEqualTo(hi, i.runts, v.compare)
+	}
+	if !v.descend(i.children[last], hi, hasHi, yield) {
+		return false
+	}
+	for index := last - 1; index >= 0; index-- {
+		if !v.descend(i.children[index], hi, false, yield) {
+			return false
+		}
+	}
+	return true
+}
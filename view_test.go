@@ -0,0 +1,420 @@
+package gobptree
+
+import (
+	"cmp"
+	"math/rand"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// ensureKeys ensures the keys are exactly the expected keys.
+func ensureKeys[K cmp.Ordered](t *testing.T, keys, expected []K) {
+	t.Helper()
+
+	if got, want := len(keys), len(expected); got != want {
+		t.Errorf("length(keys) GOT: %v; WANT: %v", got, want)
+	}
+	for i := 0; i < len(keys) && i < len(expected); i++ {
+		if got, want := keys[i], expected[i]; got != want {
+			t.Errorf("keys[%d] GOT: %v; WANT: %v", i, got, want)
+		}
+	}
+}
+
+func TestTreeView(t *testing.T) {
+	forEachKeyType(t, testTreeView[int32], testTreeView[int64], testTreeView[uint32], testTreeView[uint64], testTreeView[string])
+}
+
+func testTreeView[K cmp.Ordered](t *testing.T, k testKeys[K]) {
+	const keyCount = 200
+
+	newTree := func(order int) *Tree[K, interface{}] {
+		d, _ := NewTree[K, interface{}](order)
+		for i := 0; i < keyCount; i += 2 {
+			d.Insert(k(i), i)
+		}
+		return d
+	}
+
+	// ensureView ensures the version holds exactly the even keys below
+	// keyCount, each associated with itself.
+	ensureView := func(t *testing.T, v *ReadView[K, interface{}]) {
+		t.Helper()
+		for i := 0; i < keyCount; i++ {
+			value, ok := v.Search(k(i))
+			if want := i%2 == 0; ok != want || (ok && value != i) {
+				t.Errorf("Search(%v) GOT: %v, %v; WANT: %v, %v", i, value, ok, i, want)
+			}
+		}
+		var count int
+		for key, value := range v.All() {
+			if want := count * 2; key != k(want) || value != want {
+				t.Errorf("GOT: %v, %v; WANT: %v, %v", key, value, k(want), want)
+			}
+			count++
+		}
+		if got, want := count, keyCount/2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if got, want := v.Len(), keyCount/2; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		if key, _, ok := v.Min(); !ok || key != k(0) {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", key, ok, k(0), true)
+		}
+		if key, _, ok := v.Max(); !ok || key != k(keyCount-2) {
+			t.Errorf("GOT: %v, %v; WANT: %v, %v", key, ok, k(keyCount-2), true)
+		}
+	}
+
+	// collect returns the keys yielded by an iterator.
+	collect := func(seq func(yield func(K, interface{}) bool)) []K {
+		var keys []K
+		for key := range seq {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	t.Run("empty tree", func(t *testing.T) {
+		d, _ := NewTree[K, interface{}](4)
+		d.View(func(v *ReadView[K, interface{}]) {
+			if _, ok := v.Search(k(1)); ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, false)
+			}
+			if _, _, ok := v.Min(); ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, false)
+			}
+			if _, _, ok := v.Max(); ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, false)
+			}
+			if got := collect(v.All()); len(got) != 0 {
+				t.Errorf("GOT: %v; WANT: %v", got, nil)
+			}
+		})
+	})
+	t.Run("iterators", func(t *testing.T) {
+		d := newTree(4)
+		d.View(func(v *ReadView[K, interface{}]) {
+			ensureKeys(t, collect(v.Ascend(k(151))), k.slice(152, 154, 156, 158, 160, 162, 164, 166, 168, 170, 172, 174, 176, 178, 180, 182, 184, 186, 188, 190, 192, 194, 196, 198))
+			ensureKeys(t, collect(v.Descend(k(9))), k.slice(8, 6, 4, 2, 0))
+			ensureKeys(t, collect(v.Descend(k(8))), k.slice(8, 6, 4, 2, 0))
+			ensureKeys(t, collect(v.Range(k(10), k(20), RangeOptions{})), k.slice(10, 12, 14, 16, 18, 20))
+			ensureKeys(t, collect(v.Range(k(10), k(20), RangeOptions{ExcludeLo: true, ExcludeHi: true})), k.slice(12, 14, 16, 18))
+			ensureKeys(t, collect(v.Range(k(11), k(19), RangeOptions{ExcludeLo: true, ExcludeHi: true})), k.slice(12, 14, 16, 18))
+
+			var keys []K
+			for key := range v.Descend(k(keyCount)) {
+				if keys = append(keys, key); len(keys) == 3 {
+					break
+				}
+			}
+			ensureKeys(t, keys, k.slice(198, 196, 194))
+		})
+	})
+	t.Run("version is unaffected by the tree", func(t *testing.T) {
+		r := rand.New(rand.NewSource(1))
+		for _, order := range []int{4, 8} {
+			d := newTree(order)
+			d.View(func(v *ReadView[K, interface{}]) {
+				// Modifying the tree while enumerating the version does not wait
+				// for the enumeration to complete.
+				for range v.All() {
+					key := r.Intn(keyCount)
+					switch r.Intn(3) {
+					case 0:
+						d.Delete(k(key))
+					case 1:
+						d.DeleteRange(k(key), k(key+10))
+					default:
+						d.Insert(k(key), -1)
+					}
+				}
+				ensureView(t, v)
+			})
+			ensureStructure(t, d)
+		}
+	})
+	t.Run("views share a version until the tree is modified", func(t *testing.T) {
+		d := newTree(4)
+		d.View(func(v *ReadView[K, interface{}]) {
+			d.View(func(w *ReadView[K, interface{}]) {
+				if w != v {
+					t.Errorf("GOT: %p; WANT: %p", w, v)
+				}
+			})
+			d.Insert(k(1), 1)
+			d.View(func(w *ReadView[K, interface{}]) {
+				if w == v {
+					t.Errorf("GOT: %p; WANT: another version", w)
+				}
+				if _, ok := w.Search(k(1)); !ok {
+					t.Errorf("GOT: %v; WANT: %v", ok, true)
+				}
+			})
+			if _, ok := v.Search(k(1)); ok {
+				t.Errorf("GOT: %v; WANT: %v", ok, false)
+			}
+		})
+	})
+	t.Run("released versions are reclaimed", func(t *testing.T) {
+		d := newTree(4)
+
+		// nodes collects every node of the tree.
+		nodes := func() map[node[K, interface{}]]bool {
+			all := make(map[node[K, interface{}]]bool)
+			var walk func(n node[K, interface{}])
+			walk = func(n node[K, interface{}]) {
+				all[n] = true
+				if i, ok := n.(*internalNode[K, interface{}]); ok {
+					for _, child := range i.children {
+						walk(child)
+					}
+				}
+			}
+			walk(d.root)
+			return all
+		}
+
+		// copied returns the number of nodes of the tree that were copied by
+		// updating the value of a key.
+		copied := func(key int) int {
+			before := nodes()
+			d.Update(k(key), func(interface{}, bool) interface{} { return key })
+			var count int
+			for n := range nodes() {
+				if !before[n] {
+					count++
+				}
+			}
+			return count
+		}
+
+		var height int
+		for n := d.root; ; height++ {
+			i, ok := n.(*internalNode[K, interface{}])
+			if !ok {
+				break
+			}
+			n = i.children[0]
+		}
+
+		d.View(func(*ReadView[K, interface{}]) {})
+		// Once the first version is released, the nodes belong to no context
+		// again, and are modified in place.
+		if got, want := copied(0), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+
+		d.View(func(v *ReadView[K, interface{}]) {
+			// The nodes are shared with the version while it is read.
+			if got, want := copied(0), height+1; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		})
+		// Once released, the tree modifies the nodes it shared with the version
+		// in place.
+		if got, want := copied(0), 0; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+
+		// A snapshot taken while a version is read keeps sharing the nodes
+		// once the version is released.
+		var s *Snapshot[K, interface{}]
+		d.View(func(*ReadView[K, interface{}]) {
+			s = d.Snapshot()
+		})
+		if got, want := copied(0), height+1; got != want {
+			t.Errorf("GOT: %v; WANT: %v", got, want)
+		}
+		d.Insert(k(0), -1)
+		if value, _ := s.Search(k(0)); value != 0 {
+			t.Errorf("GOT: %v; WANT: %v", value, 0)
+		}
+		ensureStructure(t, d)
+	})
+	t.Run("leaves are linked once versions are released", func(t *testing.T) {
+		d := newTree(4)
+
+		// A cursor of the tree might follow the links of its leaves, so they
+		// are only linked again once it is closed.
+		c := d.NewScanner(k(0))
+		d.View(func(v *ReadView[K, interface{}]) {
+			// Splits and merges the leaves the version shares.
+			for i := 1; i < keyCount; i += 2 {
+				d.Insert(k(i), i)
+			}
+			for i := 0; i < keyCount/2; i++ {
+				d.Delete(k(i))
+			}
+			ensureView(t, v)
+		})
+		if d.cow.Load() == nil {
+			t.Errorf("GOT: %v; WANT: %v", nil, "context")
+		}
+		c.Close()
+
+		if got := d.cow.Load(); got != nil {
+			t.Errorf("GOT: %v; WANT: %v", got, nil)
+		}
+		ensureLeafLinks(t, d)
+		var expected []int
+		for i := keyCount / 2; i < keyCount; i++ {
+			expected = append(expected, i)
+		}
+		ensureScan(t, d, k(0), k.slice(expected...))
+		slices.Reverse(expected)
+		ensureReverseScan(t, d, k(keyCount), k.slice(expected...))
+		ensureStructure(t, d)
+
+		// The leaves of a tree that has been cloned are never linked again.
+		d.Clone()
+		d.View(func(*ReadView[K, interface{}]) {})
+		if d.cow.Load() == nil {
+			t.Errorf("GOT: %v; WANT: %v", nil, "context")
+		}
+	})
+	t.Run("concurrent", func(t *testing.T) {
+		const total = 2000
+		const viewers = 4
+
+		d, _ := NewTree[K, interface{}](4)
+
+		var wg sync.WaitGroup
+		wg.Add(2 + viewers)
+		go func() {
+			defer wg.Done()
+			// Each key is inserted after every key before it.
+			for i := 0; i < total; i++ {
+				d.Insert(k(i), i)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			// Modifies the nodes the versions might share without changing
+			// the pairs of the tree.
+			r := rand.New(rand.NewSource(1))
+			for n := 0; n < total; n++ {
+				key := r.Intn(total)
+				d.Compute(k(key), func(value interface{}, ok bool) (interface{}, ComputeAction) {
+					if !ok {
+						return nil, ComputeSkip
+					}
+					return value, ComputeStore
+				})
+			}
+		}()
+		for g := 0; g < viewers; g++ {
+			go func() {
+				defer wg.Done()
+				for n := 0; n < 50; n++ {
+					d.View(func(v *ReadView[K, interface{}]) {
+						// The version holds the keys inserted before it was
+						// taken, which are a prefix of the keys.
+						var count int
+						for key, value := range v.All() {
+							if key != k(count) || value != count {
+								t.Errorf("GOT: %v, %v; WANT: %v, %v", key, value, k(count), count)
+								return
+							}
+							count++
+						}
+						if got, want := v.Len(), count; got != want {
+							t.Errorf("GOT: %v; WANT: %v", got, want)
+						}
+					})
+				}
+			}()
+		}
+		wg.Wait()
+
+		d.View(func(v *ReadView[K, interface{}]) {
+			if got, want := v.Len(), total; got != want {
+				t.Errorf("GOT: %v; WANT: %v", got, want)
+			}
+		})
+		ensureStructure(t, d)
+	})
+	t.Run("concurrent with clones and batches", func(t *testing.T) {
+		const keyCount = 200
+		const rounds = 300
+
+		for _, order := range []int{2, 4} {
+			d, _ := NewTree[K, interface{}](order)
+			for i := 0; i < keyCount; i++ {
+				d.Insert(k(i), i)
+			}
+
+			// Every goroutine stores each key with itself as its value, so
+			// every version, clone, and batch holds the same pairs.
+			var wg sync.WaitGroup
+			wg.Add(5)
+			go func() {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(1))
+				for n := 0; n < 10*rounds; n++ {
+					if key := r.Intn(keyCount); r.Intn(2) == 0 {
+						d.Delete(k(key))
+					} else {
+						d.Insert(k(key), key)
+					}
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(2))
+				for n := 0; n < rounds; n++ {
+					c := d.Clone()
+					for i := 0; i < 100; i++ {
+						if key := r.Intn(keyCount); i%2 == 0 {
+							c.Delete(k(key))
+						} else {
+							c.Insert(k(key), key)
+						}
+					}
+					ensureStructure(t, c)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(3))
+				for n := 0; n < rounds; n++ {
+					var b Batch[K, interface{}]
+					for i := 0; i < 10; i++ {
+						key := r.Intn(keyCount)
+						b.Delete(k(key))
+						b.Insert(k(key+1), key+1)
+					}
+					d.Apply(&b)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				r := rand.New(rand.NewSource(4))
+				for n := 0; n < rounds; n++ {
+					key := r.Intn(keyCount)
+					d.Txn(func(tx *Tx[K, interface{}]) error {
+						tx.Put(k(key), key)
+						return nil
+					})
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for n := 0; n < rounds; n++ {
+					d.View(func(v *ReadView[K, interface{}]) {
+						for key, value := range v.Range(k(n%keyCount), k(n%keyCount+10), RangeOptions{}) {
+							if k(value.(int)) != key {
+								t.Errorf("GOT: %v; WANT: %v", value, key)
+							}
+						}
+					})
+				}
+			}()
+			wg.Wait()
+
+			ensureStructure(t, d)
+		}
+	})
+}